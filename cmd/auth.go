@@ -1,53 +1,72 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
-
 	"github.com/epheo/anytype-cli/internal/auth"
+	"github.com/epheo/anytype-cli/internal/client"
+	"github.com/epheo/anytype-cli/internal/clierrors"
 	"github.com/epheo/anytype-cli/internal/config"
+	"github.com/epheo/anytype-cli/internal/output"
 	"github.com/spf13/cobra"
 )
 
-// authCmd represents the auth command
-var authCmd = &cobra.Command{
-	Use:   "auth",
-	Short: "Authenticate with Anytype",
-	Long: `Authenticate with Anytype to obtain an app key.
-	
+// authStatus is the outcome of the auth command in the structured output formats
+type authStatus struct {
+	Authenticated bool   `json:"authenticated" yaml:"authenticated"`
+	BaseURL       string `json:"base_url" yaml:"base_url"`
+	// Saved reports whether new credentials were obtained and saved
+	Saved bool `json:"saved" yaml:"saved"`
+}
+
+// newAuthCmd creates the auth command
+func newAuthCmd(f *Factory) *cobra.Command {
+	var forceAuth bool
+
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Authenticate with Anytype",
+		Long: `Authenticate with Anytype to obtain an app key.
+
 This command will initiate an authentication flow that requires you to enter
 a verification code shown in your Anytype application.
-	
+
 Example:
   anytype-cli auth
   anytype-cli auth --base-url http://localhost:31009`,
-	Run: func(cmd *cobra.Command, args []string) {
-		if auth.IsAuthenticated(cfg) && !forceAuth {
-			fmt.Println("You are already authenticated.")
-			fmt.Println("To force re-authentication, use the --force flag.")
-			return
-		}
-
-		newConfig, err := auth.RunAuthentication(cfg.BaseURL)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Authentication failed: %v\n", err)
-			os.Exit(1)
-		}
-
-		// Update and save the config
-		cfg.AppKey = newConfig.AppKey
-		if err := config.SaveConfig(cfg); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to save credentials: %v\n", err)
-			os.Exit(1)
-		}
-
-		fmt.Println("Authentication successful. Credentials saved.")
-	},
-}
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if f.Authenticated() && !forceAuth {
+				switch f.OutputFormat {
+				case output.FormatJSON, output.FormatYAML:
+					return f.PrintStructured(authStatus{Authenticated: true, BaseURL: f.Config.BaseURL})
+				default:
+					f.Println("You are already authenticated.")
+					f.Println("To force re-authentication, use the --force flag.")
+				}
+				return nil
+			}
+
+			client.EnableTracing(f.Config, f.IO.ErrOut)
+			newConfig, err := auth.RunAuthentication(f.Config.BaseURL, f.IO.ErrOut, f.Prompt)
+			if err != nil {
+				return clierrors.Wrap(err, "authentication failed")
+			}
+
+			// Update and save the config
+			f.Config.AppKey = newConfig.AppKey
+			if err := config.SaveConfig(f.Config); err != nil {
+				return clierrors.Wrap(err, "failed to save credentials")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(authStatus{Authenticated: true, BaseURL: f.Config.BaseURL, Saved: true})
+			default:
+				f.Println("Authentication successful. Credentials saved.")
+			}
+			return nil
+		},
+	}
 
-var forceAuth bool
+	cmd.Flags().BoolVar(&forceAuth, "force", false, "Force re-authentication even if credentials exist")
 
-func init() {
-	rootCmd.AddCommand(authCmd)
-	authCmd.Flags().BoolVar(&forceAuth, "force", false, "Force re-authentication even if credentials exist")
+	return cmd
 }
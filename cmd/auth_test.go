@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/testserver"
+)
+
+func TestAuthWritesToFactoryStreams(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	// auth saves the credentials to the configuration shared by the tests
+	configFile := filepath.Join(os.Getenv("HOME"), ".anytype-cli", "config.yaml")
+	original, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.WriteFile(configFile, original, 0600) })
+
+	f, stdout, stderr := newTestFactory()
+	f.IO.In = strings.NewReader(testserver.VerificationCode + "\n")
+	args := []string{"--base-url", srv.URL, "auth", "--force", "-o", "json"}
+	if code := Run(context.Background(), f, args); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	if want := `"saved": true`; !strings.Contains(stdout.String(), want) || strings.Contains(stdout.String(), "verification code") {
+		t.Errorf("stdout = %q, want only the JSON status", stdout)
+	}
+	if !strings.HasSuffix(stderr.String(), "Enter verification code: ") {
+		t.Errorf("stderr = %q, want the instructions and prompt", stderr)
+	}
+	saved, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(saved), srv.URL) {
+		t.Errorf("saved config = %q", saved)
+	}
+}
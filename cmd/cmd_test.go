@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/internal/testserver"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// testNow is the clock of the commands under test
+var testNow = time.Date(2026, time.October, 16, 9, 30, 0, 0, time.UTC)
+
+// TestMain points the config at a temporary home directory holding the fake app key
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "anytype-cli-test")
+	if err != nil {
+		panic(err)
+	}
+	configDir := filepath.Join(home, ".anytype-cli")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		panic(err)
+	}
+	config := "app_key: " + testserver.AppKey + "\nbase_url: http://127.0.0.1:1\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.yaml"), []byte(config), 0600); err != nil {
+		panic(err)
+	}
+	os.Setenv("HOME", home)
+	time.Local = time.UTC
+
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
+
+// runCLI executes the CLI against the fake server and returns its stdout,
+// stderr and exit code
+func runCLI(t *testing.T, srv *testserver.Server, args ...string) (string, string, int) {
+	t.Helper()
+
+	f, stdout, stderr := newTestFactory()
+	code := Run(context.Background(), f, append([]string{"--base-url", srv.URL}, args...))
+	return stdout.String(), stderr.String(), code
+}
+
+// newTestFactory returns a Factory reading the test configuration and writing
+// to the returned buffers
+func newTestFactory() (*Factory, *bytes.Buffer, *bytes.Buffer) {
+	var stdout, stderr bytes.Buffer
+	f := NewFactory()
+	f.IO = IOStreams{In: strings.NewReader(""), Out: &stdout, ErrOut: &stderr}
+	f.Now = func() time.Time { return testNow }
+	return f, &stdout, &stderr
+}
+
+// assertGolden compares got with testdata/<name>.golden, rewriting it with -update
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("missing golden file (run go test ./cmd -update): %v", err)
+	}
+	if string(want) != got {
+		t.Errorf("output does not match %s\n--- want ---\n%s\n--- got ---\n%s", path, want, got)
+	}
+}
+
+// commandTest is a CLI invocation checked against golden files
+type commandTest struct {
+	name     string
+	args     []string
+	wantCode int
+	// formats overrides the output formats to check (default: table, json, yaml)
+	formats []string
+	// setup, if set, adjusts the fake server data for this test only
+	setup func(srv *testserver.Server)
+}
+
+func runCommandTests(t *testing.T, tests []commandTest) {
+	t.Helper()
+	for _, tt := range tests {
+		formats := tt.formats
+		if formats == nil {
+			formats = []string{output.FormatTable, output.FormatJSON, output.FormatYAML}
+		}
+		for _, format := range formats {
+			name := tt.name + "_" + format
+			t.Run(name, func(t *testing.T) {
+				srv := testserver.New()
+				defer srv.Close()
+				if tt.setup != nil {
+					srv.Lock()
+					tt.setup(srv)
+					srv.Unlock()
+				}
+
+				stdout, stderr, code := runCLI(t, srv, append(tt.args, "-o", format)...)
+				if code != tt.wantCode {
+					t.Errorf("exit code = %d, want %d (stderr: %s)", code, tt.wantCode, stderr)
+				}
+
+				got := stdout
+				if stderr != "" {
+					got += "--- stderr ---\n" + stderr
+				}
+				assertGolden(t, name, strings.ReplaceAll(got, srv.URL, "http://fake"))
+			})
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	runCommandTests(t, []commandTest{
+		{name: "error_ambiguous_space", args: []string{"objects", "list", "Eng"}, wantCode: clierrors.ExitAmbiguousName},
+		{name: "error_object_not_found", args: []string{"objects", "get", "Engineering", "obj-missing"}, wantCode: clierrors.ExitNotFound},
+		{name: "error_missing_args", args: []string{"objects", "get", "Engineering"}, wantCode: clierrors.ExitValidation,
+			formats: []string{output.FormatJSON}},
+		{name: "error_invalid_format", args: []string{"spaces", "list"}, wantCode: clierrors.ExitValidation,
+			formats: []string{"xml"}},
+	})
+}
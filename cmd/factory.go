@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/epheo/anytype-cli/internal/auth"
+	"github.com/epheo/anytype-cli/internal/client"
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/config"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+	"github.com/epheo/anytype-go"
+)
+
+// IOStreams holds the standard streams used by commands
+type IOStreams struct {
+	In     io.Reader
+	Out    io.Writer
+	ErrOut io.Writer
+}
+
+// Factory holds the dependencies of one CLI invocation. Commands are built from a
+// Factory by NewRootCmd, so a test or an embedding program can provide its own
+// configuration, Anytype client, streams and clock.
+type Factory struct {
+	IO IOStreams
+
+	// LoadConfig returns the configuration, before command-line overrides are applied
+	LoadConfig func() (*config.Config, error)
+	// NewClient builds the Anytype client from the resolved configuration
+	NewClient func(cfg *config.Config) anytype.Client
+	// NewAPI builds the direct API access, used where the SDK falls short
+	NewAPI func(cfg *config.Config) anytypecli.Service
+	// Now returns the current time
+	Now func() time.Time
+
+	// Config is the resolved configuration, set by Setup
+	Config *config.Config
+
+	// Global flag values
+	BaseURL      string
+	Verbose      bool
+	DebugHTTP    bool
+	OutputFormat string
+
+	client anytype.Client
+	api    anytypecli.Service
+	in     *bufio.Reader
+}
+
+// NewFactory returns a Factory wired to the process streams, the config file and the real API
+func NewFactory() *Factory {
+	f := &Factory{
+		IO: IOStreams{
+			In:     os.Stdin,
+			Out:    os.Stdout,
+			ErrOut: os.Stderr,
+		},
+		LoadConfig: config.LoadConfig,
+		Now:        time.Now,
+	}
+	f.NewClient = func(cfg *config.Config) anytype.Client {
+		return client.NewClient(cfg, f.IO.ErrOut)
+	}
+	f.NewAPI = func(cfg *config.Config) anytypecli.Service {
+		client.EnableTracing(cfg, f.IO.ErrOut)
+		return anytypecli.NewAPI(cfg.BaseURL, cfg.AppKey)
+	}
+	return f
+}
+
+// Setup loads the configuration once and applies the global flag overrides
+func (f *Factory) Setup() error {
+	if f.Config != nil {
+		return nil
+	}
+
+	cfg, err := f.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+
+	// Override config from command-line flags
+	if f.BaseURL != "" {
+		cfg.BaseURL = f.BaseURL
+	}
+	if f.Verbose {
+		cfg.Verbose = true
+	}
+	if f.DebugHTTP {
+		cfg.DebugHTTP = true
+	}
+
+	f.Config = cfg
+	return nil
+}
+
+// Authenticated reports whether the configuration holds an app key
+func (f *Factory) Authenticated() bool {
+	return auth.IsAuthenticated(f.Config)
+}
+
+// Client returns the Anytype client, building it on first use
+func (f *Factory) Client() anytype.Client {
+	if f.client == nil {
+		f.client = f.NewClient(f.Config)
+	}
+	return f.client
+}
+
+// API returns the direct API access, building it on first use
+func (f *Factory) API() anytypecli.Service {
+	if f.api == nil {
+		f.api = f.NewAPI(f.Config)
+	}
+	return f.api
+}
+
+// ResolveSpace returns the ID of the space designated by an ID or a name, see anytypecli.ResolveSpace
+func (f *Factory) ResolveSpace(ctx context.Context, spaceIdOrName string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	spaceID, err := anytypecli.ResolveSpace(ctx, f.Client(), spaceIdOrName)
+	if err != nil {
+		return "", clierrors.Wrap(err, "failed to resolve space")
+	}
+	return spaceID, nil
+}
+
+// ResolveType returns the key of the object type designated by a key or a name, see anytypecli.ResolveType
+func (f *Factory) ResolveType(ctx context.Context, spaceID, typeKeyOrName string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	typeKey, err := anytypecli.ResolveType(ctx, f.Client(), spaceID, typeKeyOrName)
+	if err != nil {
+		return "", clierrors.Wrap(err, "failed to resolve type")
+	}
+	return typeKey, nil
+}
+
+// ResolveList returns the ID of the list (collection or set) designated by an ID or a name, see anytypecli.ResolveList
+func (f *Factory) ResolveList(ctx context.Context, spaceID, listIdOrName string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	listID, err := anytypecli.ResolveList(ctx, f.API(), spaceID, listIdOrName)
+	if err != nil {
+		return "", clierrors.Wrap(err, "failed to resolve list")
+	}
+	return listID, nil
+}
+
+// ResolveView returns the view of a list designated by an ID or a name, see anytypecli.ResolveView
+func (f *Factory) ResolveView(ctx context.Context, spaceID, listID, viewIdOrName string) (*anytype.ListView, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	view, err := anytypecli.ResolveView(ctx, f.Client(), spaceID, listID, viewIdOrName)
+	if err != nil {
+		return nil, clierrors.Wrap(err, "failed to resolve view")
+	}
+	return view, nil
+}
+
+// ResolveTemplate returns the ID of the template of a type designated by an ID or a name, see anytypecli.ResolveTemplate
+func (f *Factory) ResolveTemplate(ctx context.Context, spaceID, typeKey, templateIdOrName string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	templateID, err := anytypecli.ResolveTemplate(ctx, f.Client(), spaceID, typeKey, templateIdOrName)
+	if err != nil {
+		return "", clierrors.Wrap(err, "failed to resolve template")
+	}
+	return templateID, nil
+}
+
+// ResolveProperty returns the property designated by an ID, a key or a name, see anytypecli.ResolveProperty
+func (f *Factory) ResolveProperty(ctx context.Context, spaceID, ref string) (*anytypecli.Property, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	property, err := anytypecli.ResolveProperty(ctx, f.API(), spaceID, ref)
+	if err != nil {
+		return nil, clierrors.Wrap(err, "failed to resolve property")
+	}
+	return property, nil
+}
+
+// ResolveTag returns the tag of a property designated by an ID, a key or a name, see anytypecli.ResolveTag
+func (f *Factory) ResolveTag(ctx context.Context, spaceID, propertyID, ref string) (*anytype.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	tag, err := anytypecli.ResolveTag(ctx, f.API(), spaceID, propertyID, ref)
+	if err != nil {
+		return nil, clierrors.Wrap(err, "failed to resolve tag")
+	}
+	return tag, nil
+}
+
+// ResolveMember returns the member of a space designated by an ID, identity, global name or name, see anytypecli.ResolveMember
+func (f *Factory) ResolveMember(ctx context.Context, spaceID, memberRef string) (*anytype.Member, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	member, err := anytypecli.ResolveMember(ctx, f.Client(), spaceID, memberRef)
+	if err != nil {
+		return nil, clierrors.Wrap(err, "failed to resolve member")
+	}
+	return member, nil
+}
+
+// Prompt writes question to the error stream and returns the line read from the input
+// stream, without surrounding spaces. At the end of the input, the answer is empty and
+// the error is io.EOF.
+func (f *Factory) Prompt(question string) (string, error) {
+	if f.in == nil {
+		f.in = bufio.NewReader(f.IO.In)
+	}
+	fmt.Fprint(f.IO.ErrOut, question)
+	answer, err := f.in.ReadString('\n')
+	if err == io.EOF {
+		// End the prompt line, the input did not
+		fmt.Fprintln(f.IO.ErrOut)
+		if strings.TrimSpace(answer) == "" {
+			return "", io.EOF
+		}
+	} else if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(answer), nil
+}
+
+// Confirm asks a yes/no question and reports whether the answer is yes. Destructive
+// commands call it unless --yes is given. Without an answer, the error is io.EOF.
+func (f *Factory) Confirm(question string) (bool, error) {
+	answer, err := f.Prompt(question + " [y/N]: ")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// Printf writes formatted output to the output stream
+func (f *Factory) Printf(format string, args ...interface{}) {
+	fmt.Fprintf(f.IO.Out, format, args...)
+}
+
+// Println writes a line to the output stream
+func (f *Factory) Println(args ...interface{}) {
+	fmt.Fprintln(f.IO.Out, args...)
+}
+
+// Print writes to the output stream
+func (f *Factory) Print(args ...interface{}) {
+	fmt.Fprint(f.IO.Out, args...)
+}
+
+// PrintStructured writes data in the selected JSON or YAML output format
+func (f *Factory) PrintStructured(data interface{}) error {
+	var formatted string
+	var err error
+	if f.OutputFormat == output.FormatYAML {
+		formatted, err = output.FormatAsYAML(data)
+	} else {
+		formatted, err = output.FormatAsJSON(data)
+	}
+	if err != nil {
+		return err
+	}
+	f.Println(formatted)
+	return nil
+}
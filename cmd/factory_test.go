@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"iter"
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/config"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+	"github.com/epheo/anytype-go"
+	"github.com/epheo/anytype-go/tests/mocks"
+)
+
+// newMockFactory returns a test Factory whose commands talk to the given mock client
+func newMockFactory(mock anytype.Client) (*Factory, *bytes.Buffer) {
+	f, stdout, _ := newTestFactory()
+	f.LoadConfig = func() (*config.Config, error) {
+		return &config.Config{AppKey: "mock-app-key", BaseURL: "http://mock"}, nil
+	}
+	f.NewClient = func(cfg *config.Config) anytype.Client {
+		return mock
+	}
+	return f, stdout
+}
+
+func TestFactoryInjectsClient(t *testing.T) {
+	mock := mocks.NewMockClient()
+	f, out := newMockFactory(mock)
+
+	if code := Run(context.Background(), f, []string{"spaces", "list"}); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d", code)
+	}
+	if !strings.Contains(out.String(), "mock-space-id") || !strings.Contains(out.String(), "Total spaces: 1") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestFactoryResolvesSpaceThroughClient(t *testing.T) {
+	mock := mocks.NewMockClient()
+	f, out := newMockFactory(mock)
+
+	if code := Run(context.Background(), f, []string{"spaces", "get", "Mock", "-o", "json"}); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d", code)
+	}
+	if !strings.Contains(out.String(), `"mock-space-id"`) {
+		t.Errorf("space name was not resolved through the injected client:\n%s", out.String())
+	}
+}
+
+// stubService serves the properties of a space and no types. The other methods of
+// anytypecli.Service are left unimplemented.
+type stubService struct {
+	anytypecli.Service
+	properties []anytypecli.Property
+}
+
+func (s *stubService) Properties(ctx context.Context, spaceID string) iter.Seq2[anytypecli.Property, error] {
+	return func(yield func(anytypecli.Property, error) bool) {
+		for _, property := range s.properties {
+			if !yield(property, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (s *stubService) Types(ctx context.Context, spaceID string) iter.Seq2[anytype.Type, error] {
+	return func(yield func(anytype.Type, error) bool) {}
+}
+
+func TestFactoryInjectsAPI(t *testing.T) {
+	f, out := newMockFactory(mocks.NewMockClient())
+	f.NewAPI = func(cfg *config.Config) anytypecli.Service {
+		return &stubService{properties: []anytypecli.Property{{ID: "prop-stub", Key: "stub_key", Name: "Stub", Format: "text"}}}
+	}
+
+	if code := Run(context.Background(), f, []string{"properties", "list", "Mock"}); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d", code)
+	}
+	if !strings.Contains(out.String(), "stub_key") {
+		t.Errorf("properties were not listed through the injected API:\n%s", out.String())
+	}
+}
+
+func TestFactoryRequiresAppKey(t *testing.T) {
+	f, _ := newMockFactory(mocks.NewMockClient())
+	f.LoadConfig = func() (*config.Config, error) {
+		return &config.Config{}, nil
+	}
+
+	if code := Run(context.Background(), f, []string{"spaces", "list"}); code != clierrors.ExitNotAuthenticated {
+		t.Errorf("exit code = %d, want %d", code, clierrors.ExitNotAuthenticated)
+	}
+}
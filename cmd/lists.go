@@ -1,233 +1,997 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/epheo/anytype-cli/internal/auth"
-	"github.com/epheo/anytype-cli/internal/client"
+	"github.com/epheo/anytype-cli/internal/clierrors"
 	"github.com/epheo/anytype-cli/internal/output"
-	"github.com/epheo/anytype-cli/internal/spaces"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+	"github.com/epheo/anytype-go"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
-// listsCmd represents the lists command
-var listsCmd = &cobra.Command{
-	Use:   "lists",
-	Short: "Manage lists and views",
-	Long:  `Interact with lists and views in Anytype spaces.`,
-}
-
-// listsViewsCmd represents the lists views command
-var listsViewsCmd = &cobra.Command{
-	Use:   "views [spaceID|spaceName] [listID]",
-	Short: "List views for a list",
-	Long:  `List all available views for the specified list in an Anytype space.`,
-	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// newListsCmd creates the lists command
+func newListsCmd(f *Factory) *cobra.Command {
+	listsCmd := &cobra.Command{
+		Use:   "lists",
+		Short: "Manage lists and views",
+		Long: `Interact with lists and views in Anytype spaces. Lists are collections, which
+hold the objects added to them, and sets, which show the objects of their source
+types. Lists are designated by ID or by name.`,
+	}
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
-		}
-		
-		listID := args[1]
+	listsCmd.AddCommand(
+		newListsLsCmd(f),
+		newListsCreateCmd(f),
+		newListsRenameCmd(f),
+		newListsDeleteCmd(f),
+		newListsViewsCmd(f),
+		newListsObjectsCmd(f),
+		newListsShowCmd(f),
+		newListsAddCmd(f),
+		newListsRemoveCmd(f),
+		newListsSyncCmd(f),
+	)
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+	return listsCmd
+}
 
-		anytypeClient := client.GetClient(cfg)
-		resp, err := anytypeClient.Space(spaceID).List(listID).Views().List(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to list views: %v\n", err)
-			os.Exit(1)
-		}
+// newListsViewsCmd creates the lists views command
+func newListsViewsCmd(f *Factory) *cobra.Command {
+	viewsCmd := &cobra.Command{
+		Use:   "views [spaceID|spaceName] [listID|listName]",
+		Short: "List views for a list",
+		Long: `List all available views for the specified list in an Anytype space.
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Data, "", "  ")
+Views are created and edited in the Anytype app: the API only reads them. To
+filter and sort the objects of a view without changing it, see 'lists objects'.`,
+		Args: exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Data)
+
+			listID, err := f.ResolveList(cmd.Context(), spaceID, args[1])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Table format with dynamic column widths
-			table := output.NewTable([]string{"VIEW ID", "NAME", "LAYOUT"})
-			for _, view := range resp.Data {
-				table.AddRow([]string{view.ID, view.Name, view.Layout})
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			resp, err := f.Client().Space(spaceID).List(listID).Views().List(ctx)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to list views")
 			}
-			fmt.Print(table.String())
-			fmt.Printf("\nTotal views: %d\n", len(resp.Data))
-			if resp.Pagination.HasMore {
-				fmt.Printf("Has more views (Total: %d, Retrieved: %d)\n",
-					resp.Pagination.Total,
-					len(resp.Data))
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(resp.Data)
+			default:
+				// Table format with dynamic column widths
+				table := output.NewTable([]string{"VIEW ID", "NAME", "LAYOUT"})
+				for _, view := range resp.Data {
+					table.AddRow([]string{view.ID, view.Name, view.Layout})
+				}
+				f.Print(table.String())
+				f.Printf("\nTotal views: %d\n", len(resp.Data))
+				if resp.Pagination.HasMore {
+					f.Printf("Has more views (Total: %d, Retrieved: %d)\n",
+						resp.Pagination.Total,
+						len(resp.Data))
+				}
 			}
-		}
-	},
-}
-
-// listsObjectsCmd represents the lists objects command
-var listsObjectsCmd = &cobra.Command{
-	Use:   "objects [spaceID|spaceName] [listID] [viewID]",
-	Short: "List objects in a view",
-	Long:  `List all objects in a specific view of a list in an Anytype space.`,
-	Args:  cobra.ExactArgs(3),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+			return nil
+		},
+	}
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
-		}
-		
-		listID := args[1]
-		viewID := args[2]
+	viewsCmd.AddCommand(newListsViewsGetCmd(f))
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+	return viewsCmd
+}
 
-		anytypeClient := client.GetClient(cfg)
-		resp, err := anytypeClient.Space(spaceID).List(listID).View(viewID).Objects().List(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to list objects in view: %v\n", err)
-			os.Exit(1)
-		}
+// newListsViewsGetCmd creates the lists views get command
+func newListsViewsGetCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [spaceID|spaceName] [listID|listName] [viewID|viewName]",
+		Short: "Get details about a view",
+		Long:  `Get the layout, filters and sorts of a view of a list.`,
+		Args:  exactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			listID, err := f.ResolveList(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+			view, err := f.ResolveView(cmd.Context(), spaceID, listID, args[2])
+			if err != nil {
+				return err
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(view)
+			default:
+				f.Printf("ID: %s\n", view.ID)
+				f.Printf("Name: %s\n", view.Name)
+				f.Printf("Layout: %s\n", view.Layout)
+				f.Println("Filters:")
+				if len(view.Filters) == 0 {
+					f.Println("  (none)")
+				}
+				for _, filter := range view.Filters {
+					f.Printf("  %s\n", anytypecli.FormatFilter(filter))
+				}
+				f.Println("Sorts:")
+				if len(view.Sorts) == 0 {
+					f.Println("  (none)")
+				}
+				for _, sort := range view.Sorts {
+					f.Printf("  %s\n", anytypecli.FormatSort(sort))
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// listsObjectsOptions holds the flags of the lists objects command
+type listsObjectsOptions struct {
+	filters []string
+	sorts   []string
+}
+
+// newListsObjectsCmd creates the lists objects command
+func newListsObjectsCmd(f *Factory) *cobra.Command {
+	opts := &listsObjectsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "objects [spaceID|spaceName] [listID|listName] [viewID|viewName]",
+		Short: "List objects in a view",
+		Long: `List all objects in a specific view of a list in an Anytype space.
+
+--filter and --sort narrow down and order the objects of the view, on top of
+its own filters and sorts, without changing the view:
+  anytype-cli lists objects Engineering "Sprint Board" All \
+    --filter 'status != Done' --filter 'due_date < today+7d' --sort due_date
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Data, "", "  ")
+Filters are written 'key operator value', with the operators =, !=, <, <=,
+>, >=, ~ (contains) and !~, or 'key is empty' and 'key is not empty'. Dates
+may be today, yesterday, tomorrow or relative such as today-2w. Sorts are
+written key, key:asc or key:desc.`,
+		Args: exactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filters, sorts, err := parseFiltersAndSorts(opts.filters, opts.sorts)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Data)
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+
+			listID, err := f.ResolveList(cmd.Context(), spaceID, args[1])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
+			}
+			view, err := f.ResolveView(cmd.Context(), spaceID, listID, args[2])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			// Every page is read, so that filters and sorts apply to all the objects
+			objects, err := anytypecli.Collect(f.API().ListObjects(ctx, spaceID, listID, view.ID))
+			if err != nil {
+				return clierrors.Wrap(err, "failed to list objects in view")
+			}
+			if objects == nil {
+				objects = []anytype.Object{}
 			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Table format with dynamic column widths
-			table := output.NewTable([]string{"OBJECT ID", "NAME", "TYPE"})
-			for _, obj := range resp.Data {
-				table.AddRow([]string{obj.ID, obj.Name, obj.TypeKey})
+			if len(filters) > 0 || len(sorts) > 0 {
+				objects = slices.DeleteFunc(objects, func(obj anytype.Object) bool {
+					return !anytypecli.MatchFilters(obj, filters, f.Now())
+				})
+				anytypecli.SortObjects(objects, sorts, f.Now())
 			}
-			fmt.Print(table.String())
-			fmt.Printf("\nTotal objects: %d\n", len(resp.Data))
-			if resp.Pagination.HasMore {
-				fmt.Printf("Has more objects (Total: %d, Retrieved: %d)\n",
-					resp.Pagination.Total,
-					len(resp.Data))
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(objects)
+			default:
+				// Table format with dynamic column widths
+				table := output.NewTable([]string{"OBJECT ID", "NAME", "TYPE"})
+				for _, obj := range objects {
+					table.AddRow([]string{obj.ID, obj.Name, obj.TypeKey})
+				}
+				f.Print(table.String())
+				f.Printf("\nTotal objects: %d\n", len(objects))
 			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&opts.filters, "filter", nil, "Filter expression, e.g. 'status = Done' (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.sorts, "sort", nil, "Sort as key, key:asc or key:desc (repeatable)")
+
+	return cmd
+}
+
+// parseFiltersAndSorts parses the --filter and --sort flags of a command
+func parseFiltersAndSorts(filterExprs, sortExprs []string) ([]anytype.ListFilter, []anytype.ListSort, error) {
+	var filters []anytype.ListFilter
+	for _, expr := range filterExprs {
+		filter, err := anytypecli.ParseFilter(expr)
+		if err != nil {
+			return nil, nil, clierrors.Validationf("%v", err)
 		}
-	},
-}
-
-// listsAddCmd represents the lists add command
-var listsAddCmd = &cobra.Command{
-	Use:   "add [spaceID|spaceName] [listID] [objectIDs...]",
-	Short: "Add objects to a list",
-	Long:  `Add one or more objects to a list in an Anytype space.`,
-	Args:  cobra.MinimumNArgs(3),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
+		filters = append(filters, filter)
+	}
+	var sorts []anytype.ListSort
+	for _, expr := range sortExprs {
+		sort, err := anytypecli.ParseSort(expr)
+		if err != nil {
+			return nil, nil, clierrors.Validationf("%v", err)
 		}
+		sorts = append(sorts, sort)
+	}
+	return filters, sorts, nil
+}
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
+// objectSelection holds the flags that select objects besides IDs given as arguments
+type objectSelection struct {
+	fromStdin bool
+	query     string
+	types     []string
+}
+
+// addQueryFlags registers the --query and --types flags
+func (sel *objectSelection) addQueryFlags(cmd *cobra.Command, action string) {
+	cmd.Flags().StringVar(&sel.query, "query", "", "Search query whose results to "+action)
+	cmd.Flags().StringSliceVar(&sel.types, "types", nil, "Type keys the search results are limited to (comma-separated)")
+}
+
+// hasQuery reports whether --query or --types was given
+func (sel *objectSelection) hasQuery(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("query") || len(sel.types) > 0
+}
+
+// checkConfirmable returns an error if the objects are read from standard input while a
+// confirmation will be asked, as the input is then exhausted and cannot answer it.
+// confirmed reports whether the command skips the confirmation.
+func (sel *objectSelection) checkConfirmable(confirmed bool) error {
+	if sel.fromStdin && !confirmed {
+		return clierrors.Validationf("--from-stdin requires --yes, as standard input cannot also answer the confirmation")
+	}
+	return nil
+}
+
+// objects returns the objects selected by the arguments, the standard input with
+// --from-stdin and the search with --query or --types, without duplicates. Only the
+// objects found by a search have a name.
+func (sel *objectSelection) objects(ctx context.Context, cmd *cobra.Command, f *Factory, spaceID string, args []string) ([]anytype.Object, error) {
+	ids := slices.Clone(args)
+	if sel.fromStdin {
+		stdinIDs, err := anytypecli.ReadIDs(f.IO.In)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
+			return nil, clierrors.Validationf("failed to read object IDs from standard input: %v", err)
 		}
-		
-		listID := args[1]
-		objectIDs := args[2:]
+		ids = append(ids, stdinIDs...)
+	}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	var objects []anytype.Object
+	for _, id := range ids {
+		objects = append(objects, anytype.Object{ID: id})
+	}
+	if sel.hasQuery(cmd) {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 		defer cancel()
 
-		anytypeClient := client.GetClient(cfg)
-		err = anytypeClient.Space(spaceID).List(listID).Objects().Add(ctx, objectIDs)
+		results, err := anytypecli.Collect(f.API().Search(ctx, spaceID, anytype.SearchRequest{Query: sel.query, Types: sel.types}))
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to add objects to list: %v\n", err)
-			os.Exit(1)
+			return nil, clierrors.Wrap(err, "failed to search")
 		}
+		objects = append(objects, results...)
+	}
 
-		fmt.Printf("Successfully added %d object(s) to list %s\n", len(objectIDs), listID)
-		for i, id := range objectIDs {
-			fmt.Printf("  %d. %s\n", i+1, id)
-		}
-	},
-}
-
-// listsRemoveCmd represents the lists remove command
-var listsRemoveCmd = &cobra.Command{
-	Use:   "remove [spaceID|spaceName] [listID] [objectID]",
-	Short: "Remove an object from a list",
-	Long:  `Remove an object from a list in an Anytype space.`,
-	Args:  cobra.ExactArgs(3),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+	seen := map[string]bool{}
+	return slices.DeleteFunc(objects, func(obj anytype.Object) bool {
+		duplicate := seen[obj.ID]
+		seen[obj.ID] = true
+		return duplicate
+	}), nil
+}
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
-		}
-		
-		listID := args[1]
-		objectID := args[2]
+// objectIDs returns the IDs of objects
+func objectIDs(objects []anytype.Object) []string {
+	ids := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		ids = append(ids, obj.ID)
+	}
+	return ids
+}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+// newListsAddCmd creates the lists add command
+func newListsAddCmd(f *Factory) *cobra.Command {
+	sel := &objectSelection{}
+
+	cmd := &cobra.Command{
+		Use:   "add [spaceID|spaceName] [listID|listName] [objectIDs...]",
+		Short: "Add objects to a list",
+		Long: `Add one or more objects to a list in an Anytype space. The objects are given
+as arguments, read from standard input with --from-stdin (one ID per line, or
+NDJSON objects with an id field), or found with --query and --types:
+  anytype-cli lists add Engineering "Sprint Board" --query "" --types ot-task
+
+The objects are sent in batches. A failed batch does not stop the next ones: the
+command then prints how many objects were added and which ones failed.`,
+		Args: minimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 2 && !sel.fromStdin && !sel.hasQuery(cmd) {
+				return clierrors.Validationf("no objects to add, give object IDs, --from-stdin, --query or --types")
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+
+			listID, err := f.ResolveList(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+			objects, err := sel.objects(cmd.Context(), cmd, f, spaceID, args[2:])
+			if err != nil {
+				return err
+			}
+			objectIDs := objectIDs(objects)
+			if len(objectIDs) == 0 {
+				f.Printf("No objects to add to list %s\n", listID)
+				return nil
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			results := anytypecli.AddToList(ctx, f.Client(), spaceID, listID, objectIDs, anytypecli.DefaultPageSize)
+			if added := results.Succeeded(); len(added) > 0 {
+				f.Printf("Successfully added %d object(s) to list %s\n", len(added), listID)
+				for i, id := range added {
+					f.Printf("  %d. %s\n", i+1, id)
+				}
+			}
+			return listFailures(f, results, "add", "to")
+		},
+	}
+
+	cmd.Flags().BoolVar(&sel.fromStdin, "from-stdin", false, "Read object IDs from standard input, one per line or as NDJSON")
+	sel.addQueryFlags(cmd, "add")
+
+	return cmd
+}
+
+// newListsRemoveCmd creates the lists remove command
+func newListsRemoveCmd(f *Factory) *cobra.Command {
+	sel := &objectSelection{}
+
+	cmd := &cobra.Command{
+		Use:   "remove [spaceID|spaceName] [listID|listName] [objectIDs...]",
+		Short: "Remove objects from a list",
+		Long: `Remove one or more objects from a list in an Anytype space. The objects are
+given as arguments, or read from standard input with --from-stdin (one ID per
+line, or NDJSON objects with an id field).`,
+		Args: minimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 2 && !sel.fromStdin {
+				return clierrors.Validationf("no objects to remove, give object IDs or --from-stdin")
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+
+			listID, err := f.ResolveList(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+			objects, err := sel.objects(cmd.Context(), cmd, f, spaceID, args[2:])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			results := anytypecli.RemoveFromList(ctx, f.Client(), spaceID, listID, objectIDs(objects))
+			for _, objectID := range results.Succeeded() {
+				f.Printf("Successfully removed object %s from list %s\n", objectID, listID)
+			}
+			return listFailures(f, results, "remove", "from")
+		},
+	}
+
+	cmd.Flags().BoolVar(&sel.fromStdin, "from-stdin", false, "Read object IDs from standard input, one per line or as NDJSON")
+
+	return cmd
+}
+
+// listFailures prints how many objects of a lists add or remove succeeded and failed, with
+// the failed IDs, and returns an error naming every failed object, classified by the
+// first failure. It returns nil if no object failed.
+func listFailures(f *Factory, results anytypecli.BulkResults, verb, preposition string) error {
+	failed := results.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	ids := make([]string, len(failed))
+	for i, result := range failed {
+		ids[i] = result.ID
+	}
+	f.Printf("\n%d succeeded, %d failed: %s\n", len(results)-len(failed), len(failed), strings.Join(ids, ", "))
+	return clierrors.Wrap(failed[0].Err, fmt.Sprintf("failed to %s %d of %d objects %s the list (%s)",
+		verb, len(failed), len(results), preposition, strings.Join(ids, ", ")))
+}
+
+// listsSyncOptions holds the flags of the lists sync command
+type listsSyncOptions struct {
+	objectSelection
+	dryRun     bool
+	allowEmpty bool
+	yes        bool
+}
+
+// listsSyncResult is the structured output of the lists sync command
+type listsSyncResult struct {
+	Added     []string `json:"added" yaml:"added"`
+	Removed   []string `json:"removed" yaml:"removed"`
+	Unchanged int      `json:"unchanged" yaml:"unchanged"`
+	DryRun    bool     `json:"dry_run" yaml:"dry_run"`
+}
+
+// newListsSyncCmd creates the lists sync command
+func newListsSyncCmd(f *Factory) *cobra.Command {
+	opts := &listsSyncOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "sync [spaceID|spaceName] [listID|listName]",
+		Short: "Make the objects of a list match a search",
+		Long: `Add the objects a search finds to a collection and remove the ones it does
+not find, so that the collection holds exactly the search results. The objects
+may also be read from standard input with --from-stdin. Use --dry-run to see
+the changes without making them:
+  anytype-cli lists sync Engineering "Open Tasks" --types ot-task --dry-run
+
+The objects to remove are listed for confirmation unless --yes is given;
+declining, or giving no answer, exits with code 9. As standard input cannot
+also answer the confirmation, --from-stdin requires --yes or --dry-run. A
+search that finds nothing would empty the list, which is refused unless
+--allow-empty is given.`,
+		Args: exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !opts.fromStdin && !opts.hasQuery(cmd) {
+				return clierrors.Validationf("nothing to sync with, give --query, --types or --from-stdin")
+			}
+			if err := opts.checkConfirmable(opts.yes || opts.dryRun); err != nil {
+				return err
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			listID, err := f.ResolveList(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+			wanted, err := opts.objects(cmd.Context(), cmd, f, spaceID, nil)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+			defer cancel()
+
+			current, err := anytypecli.Collect(f.API().CollectionObjects(ctx, spaceID, listID))
+			if err != nil {
+				return clierrors.Wrap(err, "failed to list objects in list")
+			}
+			add, remove := anytypecli.DiffIDs(objectIDs(current), objectIDs(wanted))
+			result := listsSyncResult{Added: add, Removed: remove, Unchanged: len(current) - len(remove), DryRun: opts.dryRun}
+			if len(wanted) == 0 && len(remove) > 0 && !opts.allowEmpty {
+				return clierrors.Validationf("the search found no objects, run again with --allow-empty to remove all %d objects of the list", len(remove))
+			}
+
+			names := map[string]string{}
+			for _, obj := range slices.Concat(current, wanted) {
+				if obj.Name != "" {
+					names[obj.ID] = obj.Name
+				}
+			}
+			if !opts.dryRun && len(remove) > 0 {
+				if !opts.yes {
+					for _, id := range remove {
+						fmt.Fprintf(f.IO.ErrOut, "  - %s  %s\n", id, names[id])
+					}
+				}
+				if err := confirmAction(f, opts.yes, fmt.Sprintf("Remove %d object(s) from the list?", len(remove))); err != nil {
+					return err
+				}
+			}
+
+			var syncErr error
+			if !opts.dryRun {
+				if len(add) > 0 {
+					syncErr = anytypecli.AddToList(ctx, f.Client(), spaceID, listID, add, anytypecli.DefaultPageSize).Err()
+				}
+				if syncErr == nil {
+					syncErr = anytypecli.RemoveFromList(ctx, f.Client(), spaceID, listID, remove).Err()
+				}
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				if err := f.PrintStructured(result); err != nil {
+					return err
+				}
+			default:
+				for _, id := range add {
+					f.Printf("  + %s  %s\n", id, names[id])
+				}
+				for _, id := range remove {
+					f.Printf("  - %s  %s\n", id, names[id])
+				}
+				f.Printf("\nSync: %d to add, %d to remove, %d unchanged.\n", len(add), len(remove), result.Unchanged)
+				if opts.dryRun && (len(add) > 0 || len(remove) > 0) {
+					f.Println("Dry run, the list was not changed.")
+				}
+			}
+			if syncErr != nil {
+				return clierrors.Wrap(syncErr, "failed to sync list")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.fromStdin, "from-stdin", false, "Read the object IDs from standard input instead of searching")
+	opts.addQueryFlags(cmd, "keep in the list")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Print the changes without making them")
+	cmd.Flags().BoolVar(&opts.allowEmpty, "allow-empty", false, "Empty the list when the search finds no objects")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Remove objects without asking for confirmation")
+
+	return cmd
+}
+
+// newListsLsCmd creates the lists ls command
+func newListsLsCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "ls [spaceID|spaceName]",
+		Short: "List the lists of a space",
+		Long:  `List the collections and sets of a space.`,
+		Args:  exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			lists, err := anytypecli.Collect(f.API().Lists(ctx, spaceID))
+			if err != nil {
+				return clierrors.Wrap(err, "failed to list lists")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(lists)
+			default:
+				table := output.NewTable([]string{"LIST ID", "NAME", "KIND"})
+				for _, list := range lists {
+					table.AddRow([]string{list.ID, list.Name, anytypecli.ListKind(list)})
+				}
+				f.Print(table.String())
+				f.Printf("\nTotal lists: %d\n", len(lists))
+			}
+			return nil
+		},
+	}
+}
+
+// listsCreateOptions holds the flags of the lists create command
+type listsCreateOptions struct {
+	name        string
+	kind        string
+	sourceTypes []string
+	icon        string
+}
+
+// newListsCreateCmd creates the lists create command
+func newListsCreateCmd(f *Factory) *cobra.Command {
+	opts := &listsCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create [spaceID|spaceName]",
+		Short: "Create a collection or a set",
+		Long: `Create a list. A collection (the default) starts empty, objects are added to
+it with 'lists add'. A set shows the objects of its source types:
+  anytype-cli lists create Engineering --name "All Tasks" --kind set --source-type Task`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.name == "" {
+				return clierrors.Validationf("list name is required")
+			}
+			if !slices.Contains(anytypecli.ListKinds, opts.kind) {
+				return clierrors.Validationf("invalid kind '%s' (expected one of: %s)", opts.kind, strings.Join(anytypecli.ListKinds, ", "))
+			}
+			if opts.kind == anytypecli.ListKindSet && len(opts.sourceTypes) == 0 {
+				return clierrors.Validationf("a set needs at least one --source-type")
+			}
+			if opts.kind == anytypecli.ListKindCollection && len(opts.sourceTypes) > 0 {
+				return clierrors.Validationf("--source-type only applies to sets (--kind set)")
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			var sourceTypes []string
+			for _, typeKeyOrName := range opts.sourceTypes {
+				typeKey, err := f.ResolveType(cmd.Context(), spaceID, typeKeyOrName)
+				if err != nil {
+					return err
+				}
+				sourceTypes = append(sourceTypes, typeKey)
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			createReq := anytypecli.CreateListRequest{
+				Name:        opts.name,
+				Kind:        opts.kind,
+				SourceTypes: sourceTypes,
+				Icon:        emojiIcon(opts.icon),
+			}
+
+			list, err := f.API().CreateList(ctx, spaceID, createReq)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to create list")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(list)
+			default:
+				if opts.kind == anytypecli.ListKindSet {
+					f.Printf("Set '%s' (ID: %s) created for %s.\n", list.Name, list.ID, strings.Join(sourceTypes, ", "))
+				} else {
+					f.Printf("Collection '%s' (ID: %s) created.\n", list.Name, list.ID)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name for the list (required)")
+	cmd.Flags().StringVar(&opts.kind, "kind", anytypecli.ListKindCollection, "Kind of list: collection or set")
+	cmd.Flags().StringArrayVar(&opts.sourceTypes, "source-type", nil, "Type key or name whose objects a set shows (repeatable)")
+	cmd.Flags().StringVar(&opts.icon, "icon", "", "Emoji icon for the list")
+
+	return cmd
+}
+
+// newListsRenameCmd creates the lists rename command
+func newListsRenameCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename [spaceID|spaceName] [listID|listName] [newName]",
+		Short: "Rename a list",
+		Long:  `Rename a collection or a set.`,
+		Args:  exactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			listID, err := f.ResolveList(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			list, err := f.API().UpdateObject(ctx, spaceID, listID, anytypecli.UpdateObjectRequest{Name: args[2]})
+			if err != nil {
+				return clierrors.Wrap(err, "failed to rename list")
+			}
 
-		anytypeClient := client.GetClient(cfg)
-		err = anytypeClient.Space(spaceID).List(listID).Object(objectID).Remove(ctx)
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(list)
+			default:
+				f.Printf("List %s renamed to '%s'.\n", list.ID, list.Name)
+			}
+			return nil
+		},
+	}
+}
+
+// newListsDeleteCmd creates the lists delete command
+func newListsDeleteCmd(f *Factory) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete [spaceID|spaceName] [listID|listName]",
+		Short: "Delete a list",
+		Long: `Delete (archive) a collection or a set. The objects it holds or shows are kept.
+
+The list is deleted after confirmation unless --yes is given. Declining the
+confirmation, or giving no answer, exits with code 9.`,
+		Args: exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			listID, err := f.ResolveList(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			list, err := f.Client().Space(spaceID).Object(listID).Get(ctx)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to get list")
+			}
+			if err := confirmAction(f, yes, fmt.Sprintf("Delete list '%s'?", list.Object.Name)); err != nil {
+				return err
+			}
+			resp, err := f.Client().Space(spaceID).Object(listID).Delete(ctx)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to delete list")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(resp.Object)
+			default:
+				f.Printf("List '%s' (ID: %s) deleted successfully.\n", resp.Object.Name, resp.Object.ID)
+				f.Printf("Archive status: %v\n", resp.Object.Archived)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+
+	return cmd
+}
+
+// listsShowOptions holds the flags of the lists show command
+type listsShowOptions struct {
+	groupBy string
+	columns []string
+	date    string
+	month   string
+}
+
+// listsShowResult is the structured output of the lists show command
+type listsShowResult struct {
+	View    anytype.ListView         `json:"view" yaml:"view"`
+	Objects []anytype.Object         `json:"objects" yaml:"objects"`
+	Groups  []anytypecli.ObjectGroup `json:"groups,omitempty" yaml:"groups,omitempty"`
+}
+
+// newListsShowCmd creates the lists show command
+func newListsShowCmd(f *Factory) *cobra.Command {
+	opts := &listsShowOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "show [spaceID|spaceName] [listID|listName] [viewID|viewName]",
+		Short: "Show the objects of a view in its layout",
+		Long: `Show the objects of a view in the layout of the view:
+  kanban    one column per value of the --group-by property
+  grid      a table with the --columns properties
+  calendar  the --month as a grid of weeks, objects on the day of the --date property
+Other layouts are shown as a list of names.
+
+The API does not say which property a view is grouped by, which properties it
+shows or which date it uses: unless given, they are inferred from the objects
+(the first select property, every property with a value, the first date).`,
+		Args: exactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			month := f.Now()
+			if opts.month != "" {
+				parsed, err := time.ParseInLocation("2006-01", opts.month, month.Location())
+				if err != nil {
+					return clierrors.Validationf("invalid month '%s' (expected YYYY-MM)", opts.month)
+				}
+				month = parsed
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			listID, err := f.ResolveList(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+			view, err := f.ResolveView(cmd.Context(), spaceID, listID, args[2])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			objects, err := anytypecli.Collect(f.API().ListObjects(ctx, spaceID, listID, view.ID))
+			if err != nil {
+				return clierrors.Wrap(err, "failed to list objects in view")
+			}
+
+			result := listsShowResult{View: *view, Objects: objects}
+			var render func()
+			switch view.Layout {
+			case "kanban":
+				groupBy := opts.groupBy
+				if groupBy == "" {
+					groupBy = firstOr(anytypecli.PropertyKeys(objects, "select", "multi_select"), "type")
+				}
+				tagNames, err := viewTagNames(ctx, f, spaceID, groupBy)
+				if err != nil {
+					return err
+				}
+				result.Groups = anytypecli.GroupObjects(objects, groupBy, tagNames)
+				render = func() { printBoard(f, result.Groups, groupBy) }
+			case "grid":
+				columns := opts.columns
+				if len(columns) == 0 {
+					columns = anytypecli.PropertyKeys(objects)
+				}
+				render = func() { printGrid(f, objects, columns) }
+			case "calendar":
+				date := opts.date
+				if date == "" {
+					date = firstOr(anytypecli.PropertyKeys(objects, "date"), "")
+				}
+				render = func() { printCalendar(f, objects, date, month) }
+			default:
+				render = func() {
+					for _, obj := range objects {
+						f.Printf("- %s\n", obj.Name)
+					}
+				}
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(result)
+			default:
+				f.Printf("%s (%s)\n\n", view.Name, view.Layout)
+				render()
+				f.Printf("\nTotal objects: %d\n", len(objects))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.groupBy, "group-by", "", "Property to group a kanban view by (default: the first select property)")
+	cmd.Flags().StringSliceVar(&opts.columns, "columns", nil, "Properties shown by a grid view, comma-separated (default: all)")
+	cmd.Flags().StringVar(&opts.date, "date", "", "Date property of a calendar view (default: the first date property)")
+	cmd.Flags().StringVar(&opts.month, "month", "", "Month shown by a calendar view, as YYYY-MM (default: this month)")
+
+	return cmd
+}
+
+// firstOr returns the first element of values, or fallback if there is none
+func firstOr(values []string, fallback string) string {
+	if len(values) == 0 {
+		return fallback
+	}
+	return values[0]
+}
+
+// viewTagNames returns the tag names of a select property, in order, so that a board shows
+// a column for every tag. Properties that are not found or have no tags give no names.
+func viewTagNames(ctx context.Context, f *Factory, spaceID, key string) ([]string, error) {
+	properties, err := anytypecli.Collect(f.API().Properties(ctx, spaceID))
+	if err != nil {
+		return nil, clierrors.Wrap(err, "failed to list properties")
+	}
+	for _, property := range properties {
+		if property.Key != key || !anytypecli.HasTags(property.Format) {
+			continue
+		}
+		tags, err := anytypecli.Collect(f.API().Tags(ctx, spaceID, property.ID))
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to remove object from list: %v\n", err)
-			os.Exit(1)
+			return nil, clierrors.Wrap(err, "failed to list tags")
+		}
+		names := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			names = append(names, tag.Name)
 		}
+		return names, nil
+	}
+	return nil, nil
+}
 
-		fmt.Printf("Successfully removed object %s from list %s\n", objectID, listID)
-	},
+// printBoard writes the groups of a kanban view as side-by-side columns
+func printBoard(f *Factory, groups []anytypecli.ObjectGroup, groupBy string) {
+	columns := make([]output.BoardColumn, 0, len(groups))
+	for _, group := range groups {
+		title := group.Name
+		if title == "" {
+			title = "No " + groupBy
+		}
+		column := output.BoardColumn{Title: fmt.Sprintf("%s (%d)", title, len(group.Objects))}
+		for _, obj := range group.Objects {
+			column.Cards = append(column.Cards, obj.Name)
+		}
+		columns = append(columns, column)
+	}
+	f.Print(output.Board(columns, 30))
 }
 
-func init() {
-	rootCmd.AddCommand(listsCmd)
-	listsCmd.AddCommand(listsViewsCmd)
-	listsCmd.AddCommand(listsObjectsCmd)
-	listsCmd.AddCommand(listsAddCmd)
-	listsCmd.AddCommand(listsRemoveCmd)
+// printGrid writes the objects of a grid view as a table of their properties
+func printGrid(f *Factory, objects []anytype.Object, columns []string) {
+	headers := []string{"NAME"}
+	for _, key := range columns {
+		headers = append(headers, strings.ToUpper(key))
+	}
+	table := output.NewTable(headers).SetMaxWidth(40).SetTruncate(true)
+	for _, obj := range objects {
+		row := []string{obj.Name}
+		for _, key := range columns {
+			row = append(row, anytypecli.PropertyText(obj, key))
+		}
+		table.AddRow(row)
+	}
+	f.Print(table.String())
+}
+
+// printCalendar writes the month of a calendar view, with the objects on the day of their date property
+func printCalendar(f *Factory, objects []anytype.Object, dateKey string, month time.Time) {
+	entries := map[int][]string{}
+	var undated []string
+	for _, obj := range objects {
+		date, ok := anytypecli.ParseDate(anytypecli.PropertyText(obj, dateKey), month)
+		switch {
+		case !ok:
+			undated = append(undated, obj.Name)
+		case date.Year() == month.Year() && date.Month() == month.Month():
+			entries[date.Day()] = append(entries[date.Day()], obj.Name)
+		}
+	}
+	f.Print(output.Calendar(month, entries, 12))
+	if len(undated) > 0 {
+		f.Printf("\nWithout %s: %s\n", cmp.Or(dateKey, "a date"), strings.Join(undated, ", "))
+	}
 }
@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/internal/testserver"
+	"github.com/epheo/anytype-go"
+)
+
+// objectAllTasks is the ID of the set added by addAllTasks
+const objectAllTasks = "obj-all-tasks"
+
+// addSetType adds the Set type, which lists create --kind set needs, to Engineering
+func addSetType(srv *testserver.Server) {
+	srv.Types[testserver.SpaceEngineering] = append(srv.Types[testserver.SpaceEngineering],
+		&anytype.Type{Key: "ot-set", Name: "Set", Layout: "set", RecommendedLayout: "set"})
+}
+
+// addAllTasks adds the Set type and All Tasks, a set of the tasks of Engineering with a grid
+// and a calendar view
+func addAllTasks(srv *testserver.Server) {
+	addSetType(srv)
+	space := testserver.SpaceEngineering
+	srv.Objects[space] = append(srv.Objects[space], &anytype.Object{
+		ID: objectAllTasks, Name: "All Tasks", SpaceID: space, TypeKey: "ot-set", Layout: "set",
+		Type: &anytype.Type{Key: "ot-set", Name: "Set"},
+	})
+	srv.Lists[space][objectAllTasks] = &testserver.List{
+		Views: []anytype.ListView{
+			{ID: "view-tasks-all", Name: "All", Layout: "grid"},
+			{ID: "view-tasks-calendar", Name: "Due dates", Layout: "calendar"},
+		},
+		SourceTypes: []string{"ot-task"},
+	}
+}
+
+// filterBoardView gives the board view of the sprint list a filter and a sort, which the
+// shared fixture leaves out
+func filterBoardView(srv *testserver.Server) {
+	for i, view := range srv.Lists[testserver.SpaceEngineering][testserver.ObjectSprintList].Views {
+		if view.ID == testserver.ViewKanban {
+			view.Filters = []anytype.ListFilter{{ID: "filter-open", PropertyKey: "status", Format: "select", Condition: "ne", Value: "Done"}}
+			view.Sorts = []anytype.ListSort{{ID: "sort-due", PropertyKey: "due_date", Format: "date", SortType: "asc"}}
+			srv.Lists[testserver.SpaceEngineering][testserver.ObjectSprintList].Views[i] = view
+		}
+	}
+}
+
+func TestListsCommands(t *testing.T) {
+	runCommandTests(t, []commandTest{
+		{name: "lists_views", args: []string{"lists", "views", "Engineering", testserver.ObjectSprintList}},
+		{name: "lists_objects", args: []string{"lists", "objects", "Engineering", testserver.ObjectSprintList, testserver.ViewGrid}},
+		{name: "lists_add", args: []string{"lists", "add", "Engineering", testserver.ObjectSprintList, testserver.ObjectRoadmap},
+			formats: []string{output.FormatTable}},
+		{name: "lists_remove", args: []string{"lists", "remove", "Engineering", testserver.ObjectSprintList, testserver.ObjectFixBug},
+			formats: []string{output.FormatTable}},
+		{name: "lists_remove_partial", args: []string{"lists", "remove", "Engineering", testserver.ObjectSprintList, testserver.ObjectFixBug,
+			testserver.ObjectRoadmap, "obj-missing"}, wantCode: clierrors.ExitNotFound, formats: []string{output.FormatTable}},
+		{name: "lists_add_partial", args: []string{"lists", "add", "Engineering", testserver.ObjectSprintList, testserver.ObjectRoadmap, "obj-missing"},
+			wantCode: clierrors.ExitNotFound, formats: []string{output.FormatTable}},
+		{name: "lists_views_get", args: []string{"lists", "views", "get", "Engineering", "Sprint Board", "board"}, setup: filterBoardView},
+		{name: "lists_views_get_no_filters", args: []string{"lists", "views", "get", "Engineering", "Sprint Board", testserver.ViewGrid},
+			formats: []string{output.FormatTable}},
+		{name: "lists_views_get_not_found", args: []string{"lists", "views", "get", "Engineering", "Sprint Board", "Calendar"},
+			wantCode: clierrors.ExitNotFound, formats: []string{output.FormatTable}},
+		{name: "lists_objects_filtered", args: []string{"lists", "objects", "Engineering", "All Tasks", "All",
+			"--filter", "due_date < today+30d", "--filter", "name ~ docs"}, formats: []string{output.FormatTable}, setup: addAllTasks},
+		{name: "lists_objects_sorted", args: []string{"lists", "objects", "Engineering", "All Tasks", "All", "--sort", "status:desc"},
+			formats: []string{output.FormatTable}, setup: addAllTasks},
+		{name: "lists_objects_invalid_filter", args: []string{"lists", "objects", "Engineering", "All Tasks", "All", "--filter", "status"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}, setup: addAllTasks},
+		{name: "lists_show_kanban", args: []string{"lists", "show", "Engineering", "Sprint Board", "Board"}},
+		{name: "lists_show_grid", args: []string{"lists", "show", "Engineering", "All Tasks", "All"}, formats: []string{output.FormatTable}, setup: addAllTasks},
+		{name: "lists_show_grid_columns", args: []string{"lists", "show", "Engineering", "All Tasks", "All", "--columns", "due_date,type"},
+			formats: []string{output.FormatTable}, setup: addAllTasks},
+		{name: "lists_show_calendar", args: []string{"lists", "show", "Engineering", "All Tasks", "Due dates", "--month", "2026-11"},
+			formats: []string{output.FormatTable}, setup: addAllTasks},
+		{name: "lists_show_invalid_month", args: []string{"lists", "show", "Engineering", "All Tasks", "Due dates", "--month", "November"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}, setup: addAllTasks},
+		{name: "lists_ls", args: []string{"lists", "ls", "Engineering"}, setup: addAllTasks},
+		{name: "lists_create", args: []string{"lists", "create", "Engineering", "--name", "Reading List", "--icon", "📚"}},
+		{name: "lists_create_set", args: []string{"lists", "create", "Engineering", "--name", "Pages", "--kind", "set", "--source-type", "Page"},
+			formats: []string{output.FormatTable}, setup: addSetType},
+		{name: "lists_create_set_no_source", args: []string{"lists", "create", "Engineering", "--name", "Pages", "--kind", "set"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}, setup: addSetType},
+		{name: "lists_create_invalid_kind", args: []string{"lists", "create", "Engineering", "--name", "Pages", "--kind", "board"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "lists_rename", args: []string{"lists", "rename", "Engineering", "Sprint Board", "Sprint 42"}},
+		{name: "lists_delete", args: []string{"lists", "delete", "Engineering", "all tasks", "--yes"}, formats: []string{output.FormatTable}, setup: addAllTasks},
+		{name: "lists_delete_unconfirmed", args: []string{"lists", "delete", "Engineering", "all tasks"},
+			wantCode: clierrors.ExitCancelled, formats: []string{output.FormatTable}, setup: addAllTasks},
+		{name: "lists_views_by_name", args: []string{"lists", "views", "Engineering", "Sprint"}, formats: []string{output.FormatTable}},
+	})
+}
+
+func TestListsAddStoresMembership(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	_, stderr, code := runCLI(t, srv, "lists", "add", testserver.SpaceEngineering, testserver.ObjectSprintList, testserver.ObjectRoadmap)
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	ids := srv.Lists[testserver.SpaceEngineering][testserver.ObjectSprintList].ObjectIDs
+	if len(ids) != 3 || ids[2] != testserver.ObjectRoadmap {
+		t.Errorf("list members = %v, want roadmap appended", ids)
+	}
+}
+
+func TestListsCreateSetShowsSourceObjects(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	srv.Lock()
+	addSetType(srv)
+	srv.Unlock()
+
+	if _, stderr, code := runCLI(t, srv, "lists", "create", testserver.SpaceEngineering, "--name", "Pages", "--kind", "set",
+		"--source-type", "ot-page"); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	stdout, _, _ := runCLI(t, srv, "lists", "ls", testserver.SpaceEngineering)
+	if want := "Total lists: 2"; !strings.Contains(stdout, want) {
+		t.Errorf("lists ls after create:\n%s\nwant %q", stdout, want)
+	}
+	stdout, stderr, _ := runCLI(t, srv, "lists", "objects", testserver.SpaceEngineering, "Pages", "view-new-2")
+	if !strings.Contains(stdout, testserver.ObjectRoadmap) {
+		t.Errorf("lists objects of the new set:\n%s%s\nwant the roadmap page", stdout, stderr)
+	}
+}
+
+func TestListsMembershipCommands(t *testing.T) {
+	runCommandTests(t, []commandTest{
+		{name: "lists_add_query", args: []string{"lists", "add", "Engineering", "Sprint Board", "--query", "", "--types", "ot-task"},
+			formats: []string{output.FormatTable}},
+		{name: "lists_add_no_objects", args: []string{"lists", "add", "Engineering", "Sprint Board"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "lists_remove_many", args: []string{"lists", "remove", "Engineering", "Sprint Board",
+			testserver.ObjectFixBug, testserver.ObjectWriteDocs}, formats: []string{output.FormatTable}},
+		{name: "lists_sync_dry_run", args: []string{"lists", "sync", "Engineering", "Sprint Board", "--query", "docs", "--dry-run"}},
+		{name: "lists_sync", args: []string{"lists", "sync", "Engineering", "Sprint Board", "--types", "ot-page", "--yes"},
+			formats: []string{output.FormatTable}},
+		{name: "lists_sync_unconfirmed", args: []string{"lists", "sync", "Engineering", "Sprint Board", "--types", "ot-page"},
+			wantCode: clierrors.ExitCancelled, formats: []string{output.FormatTable}},
+		{name: "lists_sync_nothing_found", args: []string{"lists", "sync", "Engineering", "Sprint Board", "--query", "typo", "--yes"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "lists_sync_allow_empty", args: []string{"lists", "sync", "Engineering", "Sprint Board", "--query", "typo", "--allow-empty", "--yes"},
+			formats: []string{output.FormatTable}},
+		{name: "lists_sync_stdin_unconfirmed", args: []string{"lists", "sync", "Engineering", "Sprint Board", "--from-stdin"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "lists_sync_no_query", args: []string{"lists", "sync", "Engineering", "Sprint Board"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+	})
+}
+
+func TestListsRemoveFromStdin(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	f, stdout, stderr := newTestFactory()
+	f.IO.In = strings.NewReader(`{"id": "` + testserver.ObjectFixBug + `", "name": "Fix bug"}` + "\n" +
+		`{"id": "` + testserver.ObjectWriteDocs + `"}` + "\n")
+	code := Run(context.Background(), f, []string{"--base-url", srv.URL,
+		"lists", "remove", testserver.SpaceEngineering, testserver.ObjectSprintList, "--from-stdin"})
+	if code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	if got := strings.Count(stdout.String(), "Successfully removed"); got != 2 {
+		t.Errorf("removed %d objects, want 2:\n%s", got, stdout)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	if ids := srv.Lists[testserver.SpaceEngineering][testserver.ObjectSprintList].ObjectIDs; len(ids) != 0 {
+		t.Errorf("list members = %v, want none", ids)
+	}
+}
+
+func TestListsSyncMatchesQuery(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	if _, stderr, code := runCLI(t, srv, "lists", "sync", testserver.SpaceEngineering, "Sprint Board", "--types", "ot-page", "--yes"); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	ids := srv.Lists[testserver.SpaceEngineering][testserver.ObjectSprintList].ObjectIDs
+	if !slices.Contains(ids, testserver.ObjectRoadmap) || slices.Contains(ids, testserver.ObjectFixBug) {
+		t.Errorf("list members = %v, want the pages only", ids)
+	}
+}
+
+func TestListsSyncKeepsListWhenNotConfirmed(t *testing.T) {
+	for _, args := range [][]string{
+		{"--types", "ot-page"},
+		{"--query", "typo", "--yes"},
+	} {
+		srv := testserver.New()
+
+		args = append([]string{"lists", "sync", testserver.SpaceEngineering, "Sprint Board"}, args...)
+		if _, _, code := runCLI(t, srv, args...); code == clierrors.ExitOK {
+			t.Errorf("%v succeeded, want an error", args)
+		}
+
+		srv.Lock()
+		if ids := srv.Lists[testserver.SpaceEngineering][testserver.ObjectSprintList].ObjectIDs; len(ids) != 2 {
+			t.Errorf("%v: list members = %v, want them unchanged", args, ids)
+		}
+		srv.Unlock()
+		srv.Close()
+	}
+}
+
+func TestListsDeleteKeepsListWhenNotConfirmed(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	f, _, stderr := newTestFactory()
+	f.IO.In = strings.NewReader("")
+	args := []string{"--base-url", srv.URL, "lists", "delete", testserver.SpaceEngineering, testserver.ObjectSprintList}
+	if code := Run(context.Background(), f, args); code != clierrors.ExitCancelled {
+		t.Fatalf("exit code = %d, want %d, stderr: %s", code, clierrors.ExitCancelled, stderr)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	for _, req := range srv.Requests {
+		if req.Method == "DELETE" {
+			t.Errorf("%s %s sent, want no deletion", req.Method, req.Path)
+		}
+	}
+}
+
+func TestListsObjectsFiltersEveryPage(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	// Enough tasks for the set to span two pages, the last one done
+	srv.Lock()
+	addAllTasks(srv)
+	for i := range 150 {
+		status := &anytype.Tag{ID: testserver.TagInProgress, Name: "In Progress"}
+		if i == 149 {
+			status = &anytype.Tag{ID: testserver.TagDone, Name: "Done"}
+		}
+		srv.Objects[testserver.SpaceEngineering] = append(srv.Objects[testserver.SpaceEngineering], &anytype.Object{
+			ID: fmt.Sprintf("obj-task-%03d", i), Name: fmt.Sprintf("Task %03d", i), SpaceID: testserver.SpaceEngineering,
+			TypeKey: "ot-task", Layout: "action",
+			Properties: []anytype.Property{{Key: "status", Name: "Status", Format: "select", Select: status}},
+		})
+	}
+	srv.Unlock()
+
+	stdout, stderr, code := runCLI(t, srv, "lists", "objects", "Engineering", "All Tasks", "All", "--filter", "status = Done", "--sort", "name:desc")
+	if code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "obj-task-149") || !strings.Contains(stdout, "Total objects: 2") {
+		t.Errorf("output = %s, want Task 149 and Fix bug", stdout)
+	}
+	if i, j := strings.Index(stdout, "obj-task-149"), strings.Index(stdout, testserver.ObjectFixBug); i > j {
+		t.Errorf("output = %s, want Task 149 first", stdout)
+	}
+}
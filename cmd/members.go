@@ -2,152 +2,418 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
+	"slices"
+	"strings"
 	"time"
 
-	"github.com/epheo/anytype-cli/internal/auth"
-	"github.com/epheo/anytype-cli/internal/client"
+	"github.com/epheo/anytype-cli/internal/clierrors"
 	"github.com/epheo/anytype-cli/internal/output"
-	"github.com/epheo/anytype-cli/internal/spaces"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+	"github.com/epheo/anytype-go"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
-// membersCmd represents the members command
-var membersCmd = &cobra.Command{
-	Use:   "members",
-	Short: "Manage space members",
-	Long:  `List and get information about members in an Anytype space.`,
+// newMembersCmd creates the members command
+func newMembersCmd(f *Factory) *cobra.Command {
+	membersCmd := &cobra.Command{
+		Use:   "members",
+		Short: "Manage space members",
+		Long: `List and get information about members in an Anytype space, approve or
+decline join requests, change roles and remove members.
+
+Invite links cannot be created through the Anytype API; share a space from the
+Anytype app, then approve the join requests here.`,
+	}
+
+	membersCmd.AddCommand(
+		newMembersListCmd(f),
+		newMembersGetCmd(f),
+		newMembersWhoisCmd(f),
+		newMembersApproveCmd(f),
+		newMembersDeclineCmd(f),
+		newMembersSetRoleCmd(f),
+		newMembersRemoveCmd(f),
+	)
+
+	return membersCmd
 }
 
-// membersListCmd represents the members list command
-var membersListCmd = &cobra.Command{
-	Use:   "list [spaceID|spaceName]",
-	Short: "List all members in a space",
-	Long:  `List all members in the specified Anytype space.`,
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// membersListOptions holds the flags of the members list command
+type membersListOptions struct {
+	allSpaces bool
+	filter    anytypecli.MemberFilter
+}
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
-		}
-		
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+// newMembersListCmd creates the members list command
+func newMembersListCmd(f *Factory) *cobra.Command {
+	opts := &membersListOptions{}
 
-		anytypeClient := client.GetClient(cfg)
-		resp, err := anytypeClient.Space(spaceID).Members().List(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to list members: %v\n", err)
-			os.Exit(1)
-		}
+	cmd := &cobra.Command{
+		Use:   "list [spaceID|spaceName]",
+		Short: "List all members in a space",
+		Long: `List all members in the specified Anytype space, or with --all-spaces the
+members of every space, one row per space and member, to audit who has access to
+what. --role, --status and --identity keep only the matching members.`,
+		Args: validateArgs(cobra.MaximumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.allSpaces != (len(args) == 0) {
+				return usageError(cmd, fmt.Errorf("give either a space or --all-spaces"))
+			}
+			if opts.filter.Role != "" {
+				if err := validateRole(opts.filter.Role); err != nil {
+					return err
+				}
+			}
+			if opts.filter.Status != "" && !slices.Contains(anytypecli.MemberStatuses, opts.filter.Status) {
+				return clierrors.Validationf("invalid status '%s' (expected one of: %s)",
+					opts.filter.Status, strings.Join(anytypecli.MemberStatuses, ", "))
+			}
+			if opts.allSpaces {
+				return listAllMembers(cmd, f, opts.filter)
+			}
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Data, "", "  ")
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Data)
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			resp, err := f.Client().Space(spaceID).Members().List(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return clierrors.Wrap(err, "failed to list members")
 			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Table format with dynamic column widths
-			table := output.NewTable([]string{"MEMBER ID", "NAME", "ROLE", "STATUS"})
-			for _, member := range resp.Data {
-				table.AddRow([]string{member.ID, member.Name, member.Role, member.Status})
+			members := slices.DeleteFunc(resp.Data, func(member anytype.Member) bool { return !opts.filter.Match(member) })
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(members)
+			default:
+				// Table format with dynamic column widths
+				table := output.NewTable([]string{"MEMBER ID", "NAME", "ROLE", "STATUS"})
+				for _, member := range members {
+					table.AddRow([]string{member.ID, member.Name, member.Role, member.Status})
+				}
+				f.Print(table.String())
+				f.Printf("\nTotal members: %d\n", len(members))
 			}
-			fmt.Print(table.String())
-			fmt.Printf("\nTotal members: %d\n", len(resp.Data))
-		}
-	},
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.allSpaces, "all-spaces", false, "List the members of every space")
+	cmd.Flags().StringVar(&opts.filter.Role, "role", "", "Only list members with this role: viewer, editor or owner")
+	cmd.Flags().StringVar(&opts.filter.Status, "status", "", "Only list members with this status, such as joining, active or removed")
+	cmd.Flags().StringVar(&opts.filter.Identity, "identity", "", "Only list the member with this identity or global name")
+
+	return cmd
 }
 
-// membersGetCmd represents the members get command
-var membersGetCmd = &cobra.Command{
-	Use:   "get [spaceID|spaceName] [memberID]",
-	Short: "Get details of a specific member",
-	Long:  `Retrieve detailed information about a specific member in an Anytype space.`,
-	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// listAllMembers prints the members of every space that match filter
+func listAllMembers(cmd *cobra.Command, f *Factory, filter anytypecli.MemberFilter) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+	defer cancel()
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
+	members := []anytypecli.SpaceMember{}
+	for member, err := range anytypecli.AllMembers(ctx, f.API()) {
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
+			return clierrors.Wrap(err, "failed to list members")
 		}
-		
-		memberID := args[1]
-
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+		if filter.Match(member.Member) {
+			members = append(members, member)
+		}
+	}
 
-		anytypeClient := client.GetClient(cfg)
-		resp, err := anytypeClient.Space(spaceID).Member(memberID).Get(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to get member details: %v\n", err)
-			os.Exit(1)
+	switch f.OutputFormat {
+	case output.FormatJSON, output.FormatYAML:
+		return f.PrintStructured(members)
+	default:
+		table := output.NewTable([]string{"SPACE", "MEMBER ID", "NAME", "GLOBAL NAME", "ROLE", "STATUS"})
+		for _, member := range members {
+			table.AddRow([]string{member.SpaceName, member.ID, member.Name, member.GlobalName, member.Role, member.Status})
 		}
+		f.Print(table.String())
+		f.Printf("\nTotal memberships: %d\n", len(members))
+	}
+	return nil
+}
+
+// newMembersWhoisCmd creates the members whois command
+func newMembersWhoisCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "whois [identity|globalName]",
+		Short: "Show the spaces a member belongs to",
+		Long: `Show every space an identity is a member of, with its role and status in each.
+The identity may also be given by its global name, such as alice.any.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			identity := args[0]
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+			defer cancel()
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Member, "", "  ")
+			filter := anytypecli.MemberFilter{Identity: identity}
+			var memberships []anytypecli.SpaceMember
+			for member, err := range anytypecli.AllMembers(ctx, f.API()) {
+				if err != nil {
+					return clierrors.Wrap(err, "failed to list members")
+				}
+				if filter.Match(member.Member) {
+					memberships = append(memberships, member)
+				}
+			}
+			if len(memberships) == 0 {
+				return &clierrors.NotFoundError{Message: fmt.Sprintf("no space has a member '%s'", identity)}
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(memberships)
+			default:
+				member := memberships[0]
+				f.Printf("Name: %s\n", member.Name)
+				f.Printf("Global Name: %s\n", member.GlobalName)
+				f.Printf("Identity: %s\n\n", member.Identity)
+
+				table := output.NewTable([]string{"SPACE ID", "SPACE", "MEMBER ID", "ROLE", "STATUS"})
+				for _, membership := range memberships {
+					table.AddRow([]string{membership.SpaceID, membership.SpaceName, membership.ID, membership.Role, membership.Status})
+				}
+				f.Print(table.String())
+				f.Printf("\nTotal spaces: %d\n", len(memberships))
+			}
+			return nil
+		},
+	}
+}
+
+// newMembersGetCmd creates the members get command
+func newMembersGetCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [spaceID|spaceName] [memberID]",
+		Short: "Get details of a specific member",
+		Long:  `Retrieve detailed information about a specific member in an Anytype space.`,
+		Args:  exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Member)
+
+			memberID := args[1]
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			resp, err := f.Client().Space(spaceID).Member(memberID).Get(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return clierrors.Wrap(err, "failed to get member details")
 			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Detailed output
-			member := resp.Member
-			fmt.Println("MEMBER DETAILS")
-			fmt.Println("--------------")
-			fmt.Printf("ID: %s\n", member.ID)
-			fmt.Printf("Name: %s\n", member.Name)
-			fmt.Printf("Global Name: %s\n", member.GlobalName)
-			fmt.Printf("Identity: %s\n", member.Identity)
-			fmt.Printf("Role: %s\n", member.Role)
-			fmt.Printf("Status: %s\n", member.Status)
-			if member.Icon != nil {
-				if member.Icon.Format == "emoji" {
-					fmt.Printf("Icon: %s\n", member.Icon.Emoji)
-				} else {
-					fmt.Printf("Icon: %s (%s)\n", member.Icon.Name, member.Icon.Format)
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(resp.Member)
+			default:
+				// Detailed output
+				member := resp.Member
+				f.Println("MEMBER DETAILS")
+				f.Println("--------------")
+				f.Printf("ID: %s\n", member.ID)
+				f.Printf("Name: %s\n", member.Name)
+				f.Printf("Global Name: %s\n", member.GlobalName)
+				f.Printf("Identity: %s\n", member.Identity)
+				f.Printf("Role: %s\n", member.Role)
+				f.Printf("Status: %s\n", member.Status)
+				if member.Icon != nil {
+					if member.Icon.Format == "emoji" {
+						f.Printf("Icon: %s\n", member.Icon.Emoji)
+					} else {
+						f.Printf("Icon: %s (%s)\n", member.Icon.Name, member.Icon.Format)
+					}
 				}
 			}
+			return nil
+		},
+	}
+}
+
+// updateMember resolves a member of a space, lets check validate the change against the
+// member and the other members, and applies req. question, if not empty, is asked for
+// confirmation unless yes is set.
+func updateMember(cmd *cobra.Command, f *Factory, args []string, req anytypecli.UpdateMemberRequest, yes bool,
+	check func(member anytype.Member, members []anytype.Member) (question string, err error)) error {
+	spaceID, err := f.ResolveSpace(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+	member, err := f.ResolveMember(cmd.Context(), spaceID, args[1])
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
+
+	resp, err := f.Client().Space(spaceID).Members().List(ctx)
+	if err != nil {
+		return clierrors.Wrap(err, "failed to list members")
+	}
+	question, err := check(*member, resp.Data)
+	if err != nil {
+		return err
+	}
+	if question != "" {
+		if err := confirmAction(f, yes, question); err != nil {
+			return err
 		}
-	},
+	}
+
+	updated, err := f.API().UpdateMember(ctx, spaceID, member.ID, req)
+	if err != nil {
+		return clierrors.Wrap(err, "failed to update member")
+	}
+
+	switch f.OutputFormat {
+	case output.FormatJSON, output.FormatYAML:
+		return f.PrintStructured(updated)
+	default:
+		f.Printf("Member %s (%s) is now %s, with role %s\n", updated.Name, updated.ID, updated.Status, updated.Role)
+	}
+	return nil
+}
+
+// checkJoining returns an error unless the member is waiting to join the space
+func checkJoining(member anytype.Member) error {
+	if member.Status != string(anytype.MemberStatusJoining) {
+		return clierrors.Validationf("%s has no pending join request (status: %s)", member.Name, member.Status)
+	}
+	return nil
+}
+
+// validateRole returns an error unless role is one of anytypecli.MemberRoles
+func validateRole(role string) error {
+	if !slices.Contains(anytypecli.MemberRoles, role) {
+		return clierrors.Validationf("invalid role '%s' (expected one of: %s)", role, strings.Join(anytypecli.MemberRoles, ", "))
+	}
+	return nil
+}
+
+// newMembersApproveCmd creates the members approve command
+func newMembersApproveCmd(f *Factory) *cobra.Command {
+	var role string
+
+	cmd := &cobra.Command{
+		Use:   "approve [spaceID|spaceName] [member]",
+		Short: "Approve a request to join a space",
+		Long: `Approve the pending join request of a member, giving it the role set by --role.
+The member is designated by ID, identity, global name or name.`,
+		Args: exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateRole(role); err != nil {
+				return err
+			}
+			req := anytypecli.UpdateMemberRequest{Status: string(anytype.MemberStatusActive), Role: role}
+			return updateMember(cmd, f, args, req, false, func(member anytype.Member, _ []anytype.Member) (string, error) {
+				return "", checkJoining(member)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&role, "role", string(anytype.MemberRoleViewer), "Role of the new member: viewer, editor or owner")
+
+	return cmd
 }
 
-func init() {
-	rootCmd.AddCommand(membersCmd)
-	membersCmd.AddCommand(membersListCmd)
-	membersCmd.AddCommand(membersGetCmd)
+// newMembersDeclineCmd creates the members decline command
+func newMembersDeclineCmd(f *Factory) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "decline [spaceID|spaceName] [member]",
+		Short: "Decline a request to join a space",
+		Long: `Decline the pending join request of a member, after confirmation unless --yes is given.
+Declining the confirmation, or giving no answer, exits with code 9.`,
+		Args: exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := anytypecli.UpdateMemberRequest{Status: string(anytype.MemberStatusDeclined)}
+			return updateMember(cmd, f, args, req, yes, func(member anytype.Member, _ []anytype.Member) (string, error) {
+				return fmt.Sprintf("Decline the request of %s (%s) to join the space?", member.Name, member.GlobalName), checkJoining(member)
+			})
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Decline without asking for confirmation")
+
+	return cmd
+}
+
+// newMembersSetRoleCmd creates the members set-role command
+func newMembersSetRoleCmd(f *Factory) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "set-role [spaceID|spaceName] [member] [viewer|editor|owner]",
+		Short: "Change the role of a member",
+		Long: `Change the role of an active member of a space. Lowering a role asks for
+confirmation unless --yes is given, and the last owner of a space cannot be demoted.
+Declining the confirmation, or giving no answer, exits with code 9.`,
+		Args: exactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			role := args[2]
+			if err := validateRole(role); err != nil {
+				return err
+			}
+			req := anytypecli.UpdateMemberRequest{Role: role}
+			return updateMember(cmd, f, args, req, yes, func(member anytype.Member, members []anytype.Member) (string, error) {
+				if member.Status != string(anytype.MemberStatusActive) {
+					return "", clierrors.Validationf("%s is not an active member (status: %s)", member.Name, member.Status)
+				}
+				if err := anytypecli.CheckLastOwner(members, member, role); err != nil {
+					return "", clierrors.Validationf("%v", err)
+				}
+				if anytypecli.CompareRoles(role, member.Role) < 0 {
+					return fmt.Sprintf("Change the role of %s from %s to %s?", member.Name, member.Role, role), nil
+				}
+				return "", nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Lower the role without asking for confirmation")
+
+	return cmd
+}
+
+// newMembersRemoveCmd creates the members remove command
+func newMembersRemoveCmd(f *Factory) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "remove [spaceID|spaceName] [member]",
+		Short: "Remove a member from a space",
+		Long: `Remove an active member from a space, after confirmation unless --yes is given.
+Declining the confirmation, or giving no answer, exits with code 9. The last owner of a
+space cannot be removed.`,
+		Args: exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := anytypecli.UpdateMemberRequest{Status: string(anytype.MemberStatusRemoved)}
+			return updateMember(cmd, f, args, req, yes, func(member anytype.Member, members []anytype.Member) (string, error) {
+				if member.Status != string(anytype.MemberStatusActive) {
+					return "", clierrors.Validationf("%s is not an active member (status: %s)", member.Name, member.Status)
+				}
+				if err := anytypecli.CheckLastOwner(members, member, ""); err != nil {
+					return "", clierrors.Validationf("%v", err)
+				}
+				return fmt.Sprintf("Remove %s (%s) from the space?", member.Name, member.GlobalName), nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Remove without asking for confirmation")
+
+	return cmd
 }
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/internal/testserver"
+	"github.com/epheo/anytype-go"
+)
+
+func TestMembersCommands(t *testing.T) {
+	runCommandTests(t, []commandTest{
+		{name: "members_list", args: []string{"members", "list", "Engineering"}},
+		{name: "members_get", args: []string{"members", "get", "Engineering", testserver.MemberAlice}},
+		{name: "members_list_all_spaces", args: []string{"members", "list", "--all-spaces"}},
+		{name: "members_list_all_spaces_owners", args: []string{"members", "list", "--all-spaces", "--role", "owner", "--status", "active"},
+			formats: []string{output.FormatTable}},
+		{name: "members_list_filtered", args: []string{"members", "list", "Engineering", "--status", "joining"},
+			formats: []string{output.FormatTable}, setup: addJoiningMember},
+		{name: "members_list_invalid_status", args: []string{"members", "list", "--all-spaces", "--status", "gone"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "members_list_space_and_all", args: []string{"members", "list", "Engineering", "--all-spaces"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatJSON}},
+		{name: "members_whois", args: []string{"members", "whois", "alice.any"}},
+		{name: "members_whois_unknown", args: []string{"members", "whois", "identity-dave"},
+			wantCode: clierrors.ExitNotFound, formats: []string{output.FormatTable}},
+		{name: "members_approve", args: []string{"members", "approve", "Engineering", "carol.any", "--role", "editor"}, setup: addJoiningMember},
+		{name: "members_approve_active", args: []string{"members", "approve", "Engineering", "Bob"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "members_decline", args: []string{"members", "decline", "Engineering", "identity-carol", "--yes"},
+			formats: []string{output.FormatTable}, setup: addJoiningMember},
+		{name: "members_set_role", args: []string{"members", "set-role", "Engineering", "Bob", "owner"},
+			formats: []string{output.FormatTable}},
+		{name: "members_set_role_invalid", args: []string{"members", "set-role", "Engineering", "Bob", "admin"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "members_set_role_last_owner", args: []string{"members", "set-role", "Engineering", "Alice", "editor", "--yes"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "members_remove_unconfirmed", args: []string{"members", "remove", "Engineering", "Bob"},
+			wantCode: clierrors.ExitCancelled, formats: []string{output.FormatTable}},
+		{name: "members_remove_last_owner", args: []string{"members", "remove", "Personal", "Alice", "--yes"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+	})
+}
+
+// addJoiningMember adds Carol, who asked to join Engineering, to the members of the shared fixture
+func addJoiningMember(srv *testserver.Server) {
+	srv.Members[testserver.SpaceEngineering] = append(srv.Members[testserver.SpaceEngineering], &anytype.Member{
+		ID: testserver.MemberCarol, Name: "Carol", GlobalName: "carol.any", Identity: "identity-carol", Role: "viewer", Status: "joining"})
+}
+
+func TestMembersRemoveDeclined(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	f, _, stderr := newTestFactory()
+	f.IO.In = strings.NewReader("n\n")
+	code := Run(context.Background(), f, []string{"--base-url", srv.URL, "members", "remove", testserver.SpaceEngineering, "Bob"})
+	if code != clierrors.ExitCancelled {
+		t.Fatalf("exit code = %d, want %d, stderr: %s", code, clierrors.ExitCancelled, stderr)
+	}
+	if want := "Error: cancelled, run again with --yes to skip the confirmation"; !strings.Contains(stderr.String(), want) {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	if req := srv.Requests[len(srv.Requests)-1]; req.Method == "PATCH" {
+		t.Errorf("declined removal sent %s %s", req.Method, req.Path)
+	}
+}
+
+func TestMembersRemoveAfterConfirmation(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	f, stdout, stderr := newTestFactory()
+	f.IO.In = strings.NewReader("y\n")
+	code := Run(context.Background(), f, []string{"--base-url", srv.URL, "members", "remove", testserver.SpaceEngineering, "Bob"})
+	if code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	if want := "Remove Bob (bob.any) from the space? [y/N]: "; stderr.String() != want {
+		t.Errorf("prompt = %q, want %q", stderr.String(), want)
+	}
+	if want := "is now removed"; !strings.Contains(stdout.String(), want) {
+		t.Errorf("output = %q, want %q", stdout.String(), want)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	if req := srv.Requests[len(srv.Requests)-1]; req.Method != "PATCH" || !strings.Contains(req.Body, `"status":"removed"`) {
+		t.Errorf("last request = %s %s %s, want the member update", req.Method, req.Path, req.Body)
+	}
+}
@@ -2,403 +2,1171 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/epheo/anytype-cli/internal/auth"
-	"github.com/epheo/anytype-cli/internal/client"
+	"github.com/epheo/anytype-cli/internal/clierrors"
 	"github.com/epheo/anytype-cli/internal/output"
-	"github.com/epheo/anytype-cli/internal/spaces"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
 	"github.com/epheo/anytype-go"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
-// objectsCmd represents the objects command
-var objectsCmd = &cobra.Command{
-	Use:   "objects",
-	Short: "Manage Anytype objects",
-	Long:  `Create, read, update, and delete Anytype objects.`,
+// newObjectsCmd creates the objects command
+func newObjectsCmd(f *Factory) *cobra.Command {
+	objectsCmd := &cobra.Command{
+		Use:   "objects",
+		Short: "Manage Anytype objects",
+		Long:  `Create, read, update, and delete Anytype objects.`,
+	}
+
+	objectsCmd.AddCommand(
+		newObjectsListCmd(f),
+		newObjectsGetCmd(f),
+		newObjectsCreateCmd(f),
+		newObjectsDeleteCmd(f),
+		newObjectsArchiveCmd(f),
+		newObjectsRestoreCmd(f),
+		newObjectsExportCmd(f),
+		newObjectsImportCmd(f),
+		newObjectsDuplicateCmd(f),
+		newObjectsCopyCmd(f),
+		newObjectsMoveCmd(f),
+		newObjectsBulkCmd(f),
+	)
+
+	return objectsCmd
 }
 
-// objectsListCmd represents the objects list command
-var objectsListCmd = &cobra.Command{
-	Use:   "list [spaceID|spaceName]",
-	Short: "List objects in a space",
-	Long:  `List all objects available in the specified space using either space ID or name.`,
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// newObjectsListCmd creates the objects list command
+func newObjectsListCmd(f *Factory) *cobra.Command {
+	var archived bool
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
-		}
+	cmd := &cobra.Command{
+		Use:               "list [spaceID|spaceName]",
+		Short:             "List objects in a space",
+		Long:              `List all objects available in the specified space using either space ID or name.`,
+		Args:              exactArgs(1),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
 
-		anytypeClient := client.GetClient(cfg)
-		objects, err := anytypeClient.Space(spaceID).Objects().List(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to list objects: %v\n", err)
-			os.Exit(1)
-		}
+			if archived {
+				objects, err := f.API().ArchivedObjects(ctx, spaceID)
+				if err != nil {
+					return clierrors.Wrap(err, "failed to list archived objects")
+				}
+				return printObjects(f, objects, "Archived objects")
+			}
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(objects, "", "  ")
+			objects, err := anytypecli.AllObjects(ctx, f.API(), spaceID)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return clierrors.Wrap(err, "failed to list objects")
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(objects)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
-			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Table format with dynamic column widths
-			table := output.NewTable([]string{"OBJECT ID", "NAME", "TYPE", "LAYOUT"})
-			// Don't truncate OBJECT ID as it's used for command line arguments
-			table.SetColumnWidth(1, 30)
-			table.SetColumnTruncate(1, true) // NAME column
-			table.SetColumnWidth(2, 20)
-			table.SetColumnTruncate(2, true) // TYPE column
-			table.SetColumnWidth(3, 20)
-			table.SetColumnTruncate(3, true) // LAYOUT column
+			return printObjects(f, objects, "Total objects")
+		},
+	}
 
-			for _, obj := range objects {
-				table.AddRow([]string{obj.ID, obj.Name, obj.TypeKey, obj.Layout})
-			}
-			fmt.Print(table.String())
-			fmt.Printf("\nTotal objects: %d\n", len(objects))
-		}
-	},
+	cmd.Flags().BoolVar(&archived, "archived", false, "List the archived objects instead")
+
+	return cmd
 }
 
-// objectsGetCmd represents the objects get command
-var objectsGetCmd = &cobra.Command{
-	Use:   "get [spaceID|spaceName] [objectID]",
-	Short: "Get details of a specific object",
-	Long:  `Retrieve detailed information about a specific Anytype object.`,
-	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// printObjects writes a list of objects, followed in table format by its length
+func printObjects(f *Factory, objects []anytype.Object, total string) error {
+	switch f.OutputFormat {
+	case output.FormatJSON, output.FormatYAML:
+		return f.PrintStructured(objects)
+	default:
+		// Table format with dynamic column widths
+		table := output.NewTable([]string{"OBJECT ID", "NAME", "TYPE", "LAYOUT"})
+		// Don't truncate OBJECT ID as it's used for command line arguments
+		table.SetColumnWidth(1, 30)
+		table.SetColumnTruncate(1, true) // NAME column
+		table.SetColumnWidth(2, 20)
+		table.SetColumnTruncate(2, true) // TYPE column
+		table.SetColumnWidth(3, 20)
+		table.SetColumnTruncate(3, true) // LAYOUT column
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
+		for _, obj := range objects {
+			table.AddRow([]string{obj.ID, obj.Name, obj.TypeKey, obj.Layout})
 		}
+		f.Print(table.String())
+		f.Printf("\n%s: %d\n", total, len(objects))
+	}
+	return nil
+}
 
-		objectID := args[1]
+// newObjectsGetCmd creates the objects get command
+func newObjectsGetCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get [spaceID|spaceName] [objectID]",
+		Short:             "Get details of a specific object",
+		Long:              `Retrieve detailed information about a specific Anytype object.`,
+		Args:              exactArgs(2),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+			objectID := args[1]
 
-		anytypeClient := client.GetClient(cfg)
-		resp, err := anytypeClient.Space(spaceID).Object(objectID).Get(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to get object: %v\n", err)
-			os.Exit(1)
-		}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Object, "", "  ")
+			resp, err := f.Client().Space(spaceID).Object(objectID).Get(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return clierrors.Wrap(err, "failed to get object")
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Object)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
-			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Detailed output
-			obj := resp.Object
-			fmt.Println("OBJECT DETAILS")
-			fmt.Println("--------------")
-			fmt.Printf("ID: %s\n", obj.ID)
-			fmt.Printf("Name: %s\n", obj.Name)
-			fmt.Printf("Type: %s\n", obj.TypeKey)
-			if obj.Type != nil {
-				fmt.Printf("Type Name: %s\n", obj.Type.Name)
-			}
-			fmt.Printf("Layout: %s\n", obj.Layout)
-			fmt.Printf("Space ID: %s\n", obj.SpaceID)
-			fmt.Printf("Archived: %v\n", obj.Archived)
-			if obj.Icon != nil {
-				fmt.Printf("Icon: %s (%s)\n", obj.Icon.Emoji, obj.Icon.Format)
-			}
-
-			if len(obj.Properties) > 0 {
-				fmt.Println("\nPROPERTIES")
-				fmt.Println("----------")
-				for _, prop := range obj.Properties {
-					fmt.Printf("%s: ", prop.Name)
-
-					switch {
-					case prop.Text != "":
-						fmt.Printf("%s\n", prop.Text)
-					case prop.Number != 0:
-						fmt.Printf("%f\n", prop.Number)
-					case prop.Select != nil:
-						fmt.Printf("%s\n", prop.Select.Name)
-					case len(prop.MultiSelect) > 0:
-						fmt.Print("[")
-						for i, sel := range prop.MultiSelect {
-							if i > 0 {
-								fmt.Print(", ")
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(resp.Object)
+			default:
+				// Detailed output
+				obj := resp.Object
+				f.Println("OBJECT DETAILS")
+				f.Println("--------------")
+				f.Printf("ID: %s\n", obj.ID)
+				f.Printf("Name: %s\n", obj.Name)
+				f.Printf("Type: %s\n", obj.TypeKey)
+				if obj.Type != nil {
+					f.Printf("Type Name: %s\n", obj.Type.Name)
+				}
+				f.Printf("Layout: %s\n", obj.Layout)
+				f.Printf("Space ID: %s\n", obj.SpaceID)
+				f.Printf("Archived: %v\n", obj.Archived)
+				if obj.Icon != nil {
+					f.Printf("Icon: %s (%s)\n", obj.Icon.Emoji, obj.Icon.Format)
+				}
+
+				if len(obj.Properties) > 0 {
+					f.Println("\nPROPERTIES")
+					f.Println("----------")
+					for _, prop := range obj.Properties {
+						f.Printf("%s: ", prop.Name)
+
+						switch {
+						case prop.Text != "":
+							f.Printf("%s\n", prop.Text)
+						case prop.Number != 0:
+							f.Printf("%f\n", prop.Number)
+						case prop.Select != nil:
+							f.Printf("%s\n", prop.Select.Name)
+						case len(prop.MultiSelect) > 0:
+							f.Print("[")
+							for i, sel := range prop.MultiSelect {
+								if i > 0 {
+									f.Print(", ")
+								}
+								f.Print(sel.Name)
 							}
-							fmt.Print(sel.Name)
+							f.Println("]")
+						default:
+							f.Println("[complex type]")
 						}
-						fmt.Println("]")
-					default:
-						fmt.Println("[complex type]")
 					}
 				}
 			}
-		}
-	},
+			return nil
+		},
+	}
 }
 
-// objectsCreateCmd represents the objects create command
-var objectsCreateCmd = &cobra.Command{
-	Use:   "create [spaceID|spaceName]",
-	Short: "Create a new object",
-	Long:  `Create a new object in the specified Anytype space.`,
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// objectsCreateOptions holds the flags of the objects create command
+type objectsCreateOptions struct {
+	name     string
+	typeKey  string
+	desc     string
+	icon     string
+	body     string
+	template string
+	vars     []string
+}
+
+// newObjectsCreateCmd creates the objects create command
+func newObjectsCreateCmd(f *Factory) *cobra.Command {
+	opts := &objectsCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create [spaceID|spaceName]",
+		Short: "Create a new object",
+		Long: `Create a new object in the specified Anytype space.
+
+--template designates a template of the --type by ID or by name. The {{name}}
+placeholders of the template body and name, and of --name, are replaced before
+the object is created, with the values of --var and the built-in variables:
+  date  today's date (2026-10-16)     week  the ISO week (2026-W42)
+  time  the current time (09:30)      year  the current year
+  me    your member name in the space
+For example:
+  anytype-cli objects create Engineering --type Page --template "Meeting Notes" \
+    --var attendees="Alice, Bob"
+
+A template with placeholders is rendered by the CLI: the object gets the
+rendered body, after which --body is added, but not the property values of the
+template. A template without placeholders is applied by Anytype. Without
+--template, --name and --body are rendered the same way when --var is given.`,
+		Args:              exactArgs(1),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Validate inputs
+			if opts.name == "" && opts.template == "" {
+				return clierrors.Validationf("object name is required")
+			}
+			if opts.typeKey == "" {
+				return clierrors.Validationf("type key is required. Use 'ot-page' for a basic page.")
+			}
+			vars := map[string]string{}
+			for _, v := range opts.vars {
+				name, value, err := anytypecli.ParseVariable(v)
+				if err != nil {
+					return clierrors.Validationf("%v", err)
+				}
+				vars[name] = value
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			typeKey, err := f.ResolveType(cmd.Context(), spaceID, opts.typeKey)
+			if err != nil {
+				return err
+			}
+
+			createReq := anytype.CreateObjectRequest{
+				TypeKey: typeKey,
+				Name:    opts.name,
+				Body:    opts.body,
+				Icon:    emojiIcon(opts.icon),
+			}
+
+			if opts.template != "" {
+				templateID, err := f.ResolveTemplate(cmd.Context(), spaceID, typeKey, opts.template)
+				if err != nil {
+					return err
+				}
+				if err := renderTemplate(cmd.Context(), f, spaceID, templateID, vars, &createReq); err != nil {
+					return err
+				}
+			} else if len(vars) > 0 {
+				// Without a template, --name and --body are rendered when variables are given
+				if err := renderVariables(cmd.Context(), f, spaceID, vars, &createReq); err != nil {
+					return err
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			resp, err := f.Client().Space(spaceID).Objects().Create(ctx, createReq)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to create object")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(resp.Object)
+			default:
+				f.Println("Object created successfully:")
+				f.Printf("ID: %s\n", resp.Object.ID)
+				f.Printf("Name: %s\n", resp.Object.Name)
+				f.Printf("Type: %s\n", resp.Object.TypeKey)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name for the new object (required without --template)")
+	cmd.Flags().StringVar(&opts.typeKey, "type", "ot-page", "Type key or name for the object (default: ot-page)")
+	cmd.Flags().StringVar(&opts.desc, "description", "", "Description for the new object")
+	cmd.Flags().StringVar(&opts.icon, "icon", "", "Emoji icon for the object (e.g. '📄')")
+	cmd.Flags().StringVar(&opts.body, "body", "", "Markdown body content for the object")
+	cmd.Flags().StringVar(&opts.template, "template", "", "Template ID or name to create the object from")
+	cmd.Flags().StringArrayVar(&opts.vars, "var", nil, "Template variable as name=value (repeatable)")
+
+	return cmd
+}
+
+// renderTemplate fills req from a template. A template with placeholders is rendered with
+// vars and the built-in variables; one without is passed to Anytype by ID.
+func renderTemplate(ctx context.Context, f *Factory, spaceID, templateID string, vars map[string]string, req *anytype.CreateObjectRequest) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := f.Client().Space(spaceID).Object(templateID).Get(ctx)
+	if err != nil {
+		return clierrors.Wrap(err, "failed to get template")
+	}
+	template := resp.Object
+
+	if req.Name == "" {
+		req.Name = template.Name
+	}
+	if len(anytypecli.Placeholders(req.Name, template.Markdown, req.Body)) == 0 {
+		req.TemplateID = templateID
+		return nil
+	}
+
+	if req.Body != "" {
+		req.Body = template.Markdown + "\n" + req.Body
+	} else {
+		req.Body = template.Markdown
+	}
+	if req.Icon == nil {
+		req.Icon = template.Icon
+	}
+	return renderVariables(ctx, f, spaceID, vars, req)
+}
 
-		// Validate inputs
-		if objectName == "" {
-			fmt.Println("Object name is required")
-			os.Exit(1)
+// renderVariables replaces the placeholders of the name and body of req, failing on the
+// placeholders that are neither in vars nor built in
+func renderVariables(ctx context.Context, f *Factory, spaceID string, vars map[string]string, req *anytype.CreateObjectRequest) error {
+	values := anytypecli.TemplateVariables(f.Now())
+	maps.Copy(values, vars)
+
+	var undefined []string
+	for _, name := range anytypecli.Placeholders(req.Name, req.Body) {
+		if _, ok := values[name]; ok {
+			continue
 		}
-		if objectTypeKey == "" {
-			fmt.Println("Type key is required. Use 'ot-page' for a basic page.")
-			os.Exit(1)
+		if name != anytypecli.VarMe {
+			undefined = append(undefined, name)
+			continue
 		}
-
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
+		me, err := anytypecli.CurrentMemberName(ctx, f.Client(), spaceID)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
+			return clierrors.Wrap(err, "failed to get the current member")
 		}
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+		values[anytypecli.VarMe] = me
+	}
+	if len(undefined) > 0 {
+		return clierrors.Validationf("undefined template variables: %s (set them with --var name=value)", strings.Join(undefined, ", "))
+	}
 
-		anytypeClient := client.GetClient(cfg)
+	req.Name = anytypecli.RenderTemplate(req.Name, values)
+	req.Body = anytypecli.RenderTemplate(req.Body, values)
+	return nil
+}
 
-		var icon *anytype.Icon
-		if objectIcon != "" {
-			icon = &anytype.Icon{
-				Format: anytype.IconFormatEmoji,
-				Emoji:  objectIcon,
+// newObjectsDeleteCmd creates the objects delete command
+func newObjectsDeleteCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete [spaceID|spaceName] [objectID]",
+		Short:             "Delete an object",
+		Long:              `Delete an Anytype object from the specified space.`,
+		Args:              exactArgs(2),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
 			}
-		}
 
-		createReq := anytype.CreateObjectRequest{
-			TypeKey: objectTypeKey,
-			Name:    objectName,
-			Body:    objectBody,
-			Icon:    icon,
-		}
+			objectID := args[1]
 
-		if objectTemplateID != "" {
-			createReq.TemplateID = objectTemplateID
-		}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
 
-		resp, err := anytypeClient.Space(spaceID).Objects().Create(ctx, createReq)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to create object: %v\n", err)
-			os.Exit(1)
-		}
+			resp, err := f.Client().Space(spaceID).Object(objectID).Delete(ctx)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to delete object")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(resp.Object)
+			default:
+				f.Printf("Object '%s' (ID: %s) deleted successfully.\n", resp.Object.Name, resp.Object.ID)
+				f.Printf("Archive status: %v\n", resp.Object.Archived)
+			}
+			return nil
+		},
+	}
+}
+
+// newObjectsArchiveCmd creates the objects archive command
+func newObjectsArchiveCmd(f *Factory) *cobra.Command {
+	var yes bool
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Object, "", "  ")
+	cmd := &cobra.Command{
+		Use:   "archive [spaceID|spaceName] [objectID...]",
+		Short: "Archive objects",
+		Long: `Move objects to the archive of their space, the bin of the Anytype app. This is
+what 'objects delete' does: the API never deletes objects permanently. Archived objects
+are listed by 'trash list' and recreated as new objects by 'objects restore'.
+
+The objects are archived after confirmation unless --yes is given. Declining the
+confirmation, or giving no answer, exits with code 9.`,
+		Args:              minimumNArgs(2),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
+			}
+			if err := confirmAction(f, yes, fmt.Sprintf("Archive %d object(s)?", len(args)-1)); err != nil {
+				return err
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Object)
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			results := anytypecli.DeleteObjects(ctx, f.Client(), spaceID, args[1:])
+			for _, objectID := range results.Succeeded() {
+				f.Printf("Archived object %s\n", objectID)
+			}
+			if failed := results.Failed(); len(failed) > 0 {
+				return clierrors.Wrap(failed[0].Err, fmt.Sprintf("failed to archive object %s", failed[0].ID))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+
+	return cmd
+}
+
+// newObjectsRestoreCmd creates the objects restore command
+func newObjectsRestoreCmd(f *Factory) *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "restore [spaceID|spaceName] [objectID...]",
+		Short: "Recreate archived objects as new objects",
+		Long: `Recreate archived objects in their space from their content.
+
+The Anytype API cannot unarchive an object, so each one is recreated from its archived
+content: the new object has the type, name, icon, body and properties of the original
+but a new ID, and links to the original still point to the archived object. Objects
+can be unarchived in place from the bin of the Anytype app.
+
+The new object records the ID of the archived one in its restored_from property, which
+is created if the space lacks it. An object that was already restored is refused, so
+that running the command again does not make duplicates, unless --force is given.`,
+		Args:              minimumNArgs(2),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
-			}
-			fmt.Println(string(yamlOutput))
-		default:
-			fmt.Println("Object created successfully:")
-			fmt.Printf("ID: %s\n", resp.Object.ID)
-			fmt.Printf("Name: %s\n", resp.Object.Name)
-			fmt.Printf("Type: %s\n", resp.Object.TypeKey)
-		}
-	},
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Minute)
+			defer cancel()
+
+			copier := anytypecli.NewCopier(f.Client(), f.API(), spaceID, spaceID)
+			// The space has the types and properties of its objects, only the
+			// restored_from property may be missing
+			copier.CreateMissing = true
+
+			var copies map[string][]string
+			if !force {
+				if copies, err = anytypecli.RestoredCopies(ctx, f.API(), spaceID); err != nil {
+					return clierrors.Wrap(err, "failed to list objects")
+				}
+			}
+			var archived []anytype.Object
+			for _, objectID := range args[1:] {
+				obj, err := copier.Read(ctx, objectID)
+				if err != nil {
+					return clierrors.Wrap(err, fmt.Sprintf("failed to read object %s", objectID))
+				}
+				if !obj.Archived {
+					return clierrors.Validationf("object %s (%s) is not archived", obj.Name, obj.ID)
+				}
+				if existing := copies[obj.ID]; len(existing) > 0 {
+					return clierrors.Validationf("object %s (%s) was already restored as %s, run again with --force to restore it again",
+						obj.Name, obj.ID, strings.Join(existing, ", "))
+				}
+				archived = append(archived, *obj)
+			}
+
+			var restored []anytypecli.CopiedObject
+			for _, obj := range archived {
+				created, err := copier.Copy(ctx, anytypecli.MarkRestored(obj))
+				if err != nil {
+					return clierrors.Wrap(err, fmt.Sprintf("failed to restore object %s", obj.ID))
+				}
+				restored = append(restored, anytypecli.CopiedObject{SourceID: obj.ID, ID: created.ID, Name: created.Name, TypeKey: created.TypeKey})
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(restored)
+			default:
+				for _, obj := range restored {
+					f.Printf("Restored '%s' (archived %s) as %s\n", obj.Name, obj.SourceID, obj.ID)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Restore objects even if they were already restored")
+
+	return cmd
 }
 
-// objectsDeleteCmd represents the objects delete command
-var objectsDeleteCmd = &cobra.Command{
-	Use:   "delete [spaceID|spaceName] [objectID]",
-	Short: "Delete an object",
-	Long:  `Delete an Anytype object from the specified space.`,
-	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// newObjectsExportCmd creates the objects export command
+func newObjectsExportCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:               "export [spaceID|spaceName] [objectID]",
+		Short:             "Export an object",
+		Long:              `Export an Anytype object in markdown format.`,
+		Args:              exactArgs(2),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
-		}
+			objectID := args[1]
 
-		objectID := args[1]
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+			markdown, err := anytypecli.ExportMarkdown(ctx, f.Client(), spaceID, objectID)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to export object")
+			}
 
-		anytypeClient := client.GetClient(cfg)
-		resp, err := anytypeClient.Space(spaceID).Object(objectID).Delete(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to delete object: %v\n", err)
-			os.Exit(1)
-		}
+			switch f.OutputFormat {
+			case output.FormatJSON:
+				return f.PrintStructured(anytype.ExportResult{Markdown: markdown})
+			default:
+				f.Println(markdown)
+			}
+			return nil
+		},
+	}
+}
+
+// objectsImportOptions holds the flags of the objects import command
+type objectsImportOptions struct {
+	name       string
+	typeKey    string
+	icon       string
+	templateID string
+}
+
+// newObjectsImportCmd creates the objects import command
+func newObjectsImportCmd(f *Factory) *cobra.Command {
+	opts := &objectsImportOptions{}
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Object, "", "  ")
+	cmd := &cobra.Command{
+		Use:   "import [spaceID|spaceName] [file...]",
+		Short: "Import markdown files as objects",
+		Long: `Create one object per markdown file in the specified Anytype space.
+
+The object name is taken from the '# ' heading on the first line of the file,
+which is removed from the body, unless --name is given. Use '-' to read the
+markdown from standard input.`,
+		Args:              minimumNArgs(2),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files := args[1:]
+			if opts.name != "" && len(files) > 1 {
+				return clierrors.Validationf("--name can only be used when importing a single file")
+			}
+
+			documents := make([]string, len(files))
+			for i, file := range files {
+				var data []byte
+				var err error
+				if file == "-" {
+					data, err = io.ReadAll(f.IO.In)
+				} else {
+					data, err = os.ReadFile(file)
+				}
+				if err != nil {
+					return clierrors.Validationf("failed to read %s: %v", file, err)
+				}
+				documents[i] = string(data)
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Object)
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			imported := []*anytype.Object{}
+			for i, document := range documents {
+				obj, err := anytypecli.ImportMarkdown(ctx, f.Client(), spaceID, document, anytypecli.ImportOptions{
+					Name:       opts.name,
+					TypeKey:    opts.typeKey,
+					TemplateID: opts.templateID,
+					Icon:       opts.icon,
+				})
+				if errors.Is(err, anytypecli.ErrNoName) {
+					return clierrors.Validationf("%s: %v", files[i], err)
+				}
+				if err != nil {
+					return clierrors.Wrap(err, fmt.Sprintf("failed to import %s", files[i]))
+				}
+				imported = append(imported, obj)
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(imported)
+			default:
+				table := output.NewTable([]string{"OBJECT ID", "NAME", "TYPE", "FILE"})
+				table.SetColumnWidth(1, 30)
+				table.SetColumnTruncate(1, true) // NAME column
+				for i, obj := range imported {
+					table.AddRow([]string{obj.ID, obj.Name, obj.TypeKey, files[i]})
+				}
+				f.Print(table.String())
+				f.Printf("\nImported objects: %d\n", len(imported))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name for the object, instead of the markdown heading (single file only)")
+	cmd.Flags().StringVar(&opts.typeKey, "type", anytypecli.DefaultTypeKey, "Type key for the objects")
+	cmd.Flags().StringVar(&opts.icon, "icon", "", "Emoji icon for the objects (e.g. '📄')")
+	cmd.Flags().StringVar(&opts.templateID, "template", "", "Template ID to use for creating the objects")
+
+	return cmd
+}
+
+// objectsDuplicateOptions holds the flags of the objects duplicate command
+type objectsDuplicateOptions struct {
+	name       string
+	set        []string
+	addToLists bool
+}
+
+// newObjectsDuplicateCmd creates the objects duplicate command
+func newObjectsDuplicateCmd(f *Factory) *cobra.Command {
+	opts := &objectsDuplicateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "duplicate [spaceID|spaceName] [objectID]",
+		Short: "Duplicate an object",
+		Long: `Create a new object with the type, name, icon, body and properties of an existing
+one, such as the page of the previous sprint.
+
+--name renames the copy and --set key=value, which can be repeated, overrides a property;
+select values are tag names, multi-select values comma-separated tag names, and dates
+may be relative (today+14d). --add-to-lists adds the copy to the collections holding
+the original.`,
+		Args:              exactArgs(2),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var assignments []anytypecli.Assignment
+			for _, s := range opts.set {
+				assignment, err := anytypecli.ParseAssignment(s)
+				if err != nil {
+					return clierrors.Validationf("%v", err)
+				}
+				assignments = append(assignments, assignment)
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(yamlOutput))
-		default:
-			fmt.Printf("Object '%s' (ID: %s) deleted successfully.\n", resp.Object.Name, resp.Object.ID)
-			fmt.Printf("Archive status: %v\n", resp.Object.Archived)
-		}
-	},
+			objectID := args[1]
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), time.Minute)
+			defer cancel()
+
+			copier := anytypecli.NewCopier(f.Client(), f.API(), spaceID, spaceID)
+			obj, err := copier.Read(ctx, objectID)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to get object")
+			}
+			if opts.name != "" {
+				obj.Name = opts.name
+			}
+			for _, assignment := range assignments {
+				property, err := anytypecli.AssignProperty(ctx, f.API(), spaceID, assignment, f.Now())
+				if err != nil {
+					return clierrors.Wrap(err, fmt.Sprintf("invalid value for %s", assignment.Key))
+				}
+				obj.Properties = anytypecli.SetProperty(obj.Properties, property)
+			}
+
+			var lists []anytype.Object
+			if opts.addToLists {
+				if lists, err = anytypecli.CollectionsOf(ctx, f.API(), spaceID, objectID); err != nil {
+					return clierrors.Wrap(err, "failed to find the lists of the object")
+				}
+			}
+
+			created, err := copier.Copy(ctx, *obj)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to duplicate object")
+			}
+			for _, list := range lists {
+				if err := anytypecli.AddToList(ctx, f.Client(), spaceID, list.ID, []string{created.ID}, 0).Err(); err != nil {
+					return clierrors.Wrap(err, fmt.Sprintf("object %s was created but could not be added to list %s", created.ID, list.Name))
+				}
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(created)
+			default:
+				f.Println("Object duplicated successfully:")
+				f.Printf("ID: %s\n", created.ID)
+				f.Printf("Name: %s\n", created.Name)
+				f.Printf("Type: %s\n", created.TypeKey)
+				for _, list := range lists {
+					f.Printf("Added to list: %s (%s)\n", list.Name, list.ID)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name of the copy (default: that of the original)")
+	cmd.Flags().StringArrayVar(&opts.set, "set", nil, "Property value of the copy, as key=value (repeatable)")
+	cmd.Flags().BoolVar(&opts.addToLists, "add-to-lists", false, "Add the copy to the collections holding the original")
+
+	return cmd
+}
+
+// objectsCopyOptions holds the flags of the objects copy and move commands
+type objectsCopyOptions struct {
+	createMissing bool
+	recursive     bool
+	depth         int
+	yes           bool
+}
+
+// addFlags registers the flags shared by the objects copy and move commands
+func (opts *objectsCopyOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&opts.createMissing, "create-missing", false, "Create the types, properties and tags the destination space lacks")
+	cmd.Flags().BoolVarP(&opts.recursive, "recursive", "r", false, "Also copy the objects linked from the object, and those they link to")
+	cmd.Flags().IntVar(&opts.depth, "depth", 0, "Number of links followed away from the object with --recursive, 0 for no limit")
+}
+
+// newObjectsCopyCmd creates the objects copy command
+func newObjectsCopyCmd(f *Factory) *cobra.Command {
+	opts := &objectsCopyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "copy [srcSpace] [objectID] [dstSpace]",
+		Short: "Copy an object to another space",
+		Long: `Copy an object, with its body, icon and properties, to another space, or within
+a space. Select values are matched to the tags of the destination by name.
+
+The type, properties and tags of the object must exist in the destination space;
+--create-missing creates the ones it lacks from those of the source space.
+--recursive also copies the objects it links to, in turn, and points the links
+between the copies at the copies; --depth limits how many links away it goes.`,
+		Args:              exactArgs(3),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transferObject(cmd, f, args, opts, false)
+		},
+	}
+	opts.addFlags(cmd)
+
+	return cmd
+}
+
+// newObjectsMoveCmd creates the objects move command
+func newObjectsMoveCmd(f *Factory) *cobra.Command {
+	opts := &objectsCopyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "move [srcSpace] [objectID] [dstSpace]",
+		Short: "Move an object to another space",
+		Long: `Move an object to another space: copy it as objects copy does, then archive the
+original once the copy succeeded. With --recursive the linked objects are moved too.
+
+The API cannot move an object, so the copy gets a new ID, and links to the original
+from objects that were not moved still point to the archived original.
+
+The objects to move, including the linked ones with --recursive, are listed for
+confirmation unless --yes is given; declining, or giving no answer, exits with
+code 9 before anything is copied. --depth limits how many links away --recursive
+goes:
+  anytype-cli objects move Personal <object-id> Engineering --recursive --depth 1`,
+		Args:              exactArgs(3),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return transferObject(cmd, f, args, opts, true)
+		},
+	}
+	opts.addFlags(cmd)
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Do not list the objects and ask for confirmation")
+
+	return cmd
 }
 
-// objectsExportCmd represents the objects export command
-var objectsExportCmd = &cobra.Command{
-	Use:   "export [spaceID|spaceName] [objectID]",
-	Short: "Export an object",
-	Long:  `Export an Anytype object in markdown format.`,
-	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
+// transferObject copies an object between spaces and, if move is set, archives the
+// originals after confirmation
+func transferObject(cmd *cobra.Command, f *Factory, args []string, opts *objectsCopyOptions, move bool) error {
+	if opts.depth < 0 {
+		return clierrors.Validationf("--depth must be 0 or more")
+	}
+	if cmd.Flags().Changed("depth") && !opts.recursive {
+		return clierrors.Validationf("--depth requires --recursive")
+	}
+	depth := 0
+	if opts.recursive {
+		depth = -1
+		if opts.depth > 0 {
+			depth = opts.depth
 		}
+	}
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
+	fromID, err := f.ResolveSpace(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+	toID, err := f.ResolveSpace(cmd.Context(), args[2])
+	if err != nil {
+		return err
+	}
+	if move && fromID == toID {
+		return clierrors.Validationf("the object is already in this space")
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+	defer cancel()
+
+	copier := anytypecli.NewCopier(f.Client(), f.API(), fromID, toID)
+	copier.CreateMissing = opts.createMissing
+	planned, err := anytypecli.PlanCopy(ctx, copier, []string{args[1]}, depth)
+	if err != nil {
+		return wrapCopyError(err, "failed to read object")
+	}
+	if move {
+		if !opts.yes {
+			table := output.NewTable([]string{"OBJECT ID", "NAME", "TYPE", "FROM"})
+			for i, obj := range planned {
+				from := "argument"
+				if i > 0 {
+					from = "link"
+				}
+				table.AddRow([]string{obj.ID, obj.Name, obj.TypeKey, from})
+			}
+			fmt.Fprint(f.IO.ErrOut, table.String())
+		}
+		question := fmt.Sprintf("Copy %d object(s) to %s and archive the originals?", len(planned), args[2])
+		if err := confirmAction(f, opts.yes, question); err != nil {
+			return err
+		}
+	}
+
+	copied, err := anytypecli.CopyPlanned(ctx, copier, planned)
+	if err != nil {
+		if len(copied) > 0 {
+			err = fmt.Errorf("%w (%d objects were copied before the failure)", err, len(copied))
 		}
+		return wrapCopyError(err, "failed to copy object")
+	}
 
-		objectID := args[1]
+	verb := "Copied"
+	if move {
+		verb = "Moved"
+		var sourceIDs []string
+		for _, obj := range copied {
+			sourceIDs = append(sourceIDs, obj.SourceID)
+		}
+		if err := anytypecli.DeleteObjects(ctx, f.Client(), fromID, sourceIDs).Err(); err != nil {
+			return clierrors.Wrap(err, "the objects were copied but archiving the originals failed")
+		}
+	}
+
+	switch f.OutputFormat {
+	case output.FormatJSON, output.FormatYAML:
+		return f.PrintStructured(copied)
+	default:
+		table := output.NewTable([]string{"SOURCE ID", "NEW ID", "NAME", "TYPE"})
+		for _, obj := range copied {
+			table.AddRow([]string{obj.SourceID, obj.ID, obj.Name, obj.TypeKey})
+		}
+		f.Print(table.String())
+		f.Printf("\n%s %d object(s).\n", verb, len(copied))
+		if move {
+			f.Println("The originals were archived.")
+		}
+	}
+	return nil
+}
+
+// wrapCopyError wraps an error of objects copy or move, pointing at --create-missing when
+// the destination space lacks a type, property or tag
+func wrapCopyError(err error, msg string) error {
+	var missing *anytypecli.MissingError
+	if errors.As(err, &missing) {
+		return clierrors.Validationf("%s: %s, run again with --create-missing to create it", msg, missing)
+	}
+	return clierrors.Wrap(err, msg)
+}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+// objectsBulkOptions holds the flags of the objects bulk command
+type objectsBulkOptions struct {
+	sel         objectSelection
+	filters     []string
+	set         []string
+	addTags     []string
+	removeTags  []string
+	typeKey     string
+	archive     bool
+	concurrency int
+	yes         bool
+}
+
+// objectsBulkResult is the outcome of objects bulk for one object
+type objectsBulkResult struct {
+	ID     string `json:"id" yaml:"id"`
+	Name   string `json:"name" yaml:"name"`
+	Result string `json:"result" yaml:"result"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// newObjectsBulkCmd creates the objects bulk command
+func newObjectsBulkCmd(f *Factory) *cobra.Command {
+	opts := &objectsBulkOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "bulk [spaceID|spaceName] [objectIDs...]",
+		Short: "Change many objects at once",
+		Long: `Apply the same changes to many objects of a space. The objects are given as
+arguments, read from standard input with --from-stdin, or found with --query and
+--types; --filter then keeps those matching every filter. --filter alone selects
+among all the objects of the space.
+
+The changes are --set key=value, --add-tag and --remove-tag key=tag (all repeatable),
+--change-type and --archive. The selected objects and the changes are shown for
+confirmation unless --yes is given; declining, or giving no answer, exits with code 9.
+As standard input cannot also answer the confirmation, --from-stdin requires --yes.
+Objects are updated --concurrency at a time, with a progress bar on a terminal, and
+the outcome is reported for each object. The command exits with code 10 when some
+objects could not be changed:
+  anytype-cli objects bulk Engineering --types ot-task --filter 'status = Done' --archive`,
+		Args:              minimumNArgs(1),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 && !opts.sel.fromStdin && !opts.sel.hasQuery(cmd) && len(opts.filters) == 0 {
+				return clierrors.Validationf("no objects selected, give object IDs, --from-stdin, --query, --types or --filter")
+			}
+			if len(opts.set)+len(opts.addTags)+len(opts.removeTags) == 0 && opts.typeKey == "" && !opts.archive {
+				return clierrors.Validationf("nothing to do, give --set, --add-tag, --remove-tag, --change-type or --archive")
+			}
+			if opts.concurrency < 1 {
+				return clierrors.Validationf("--concurrency must be at least 1")
+			}
+			if err := opts.sel.checkConfirmable(opts.yes); err != nil {
+				return err
+			}
+			filters, _, err := parseFiltersAndSorts(opts.filters, nil)
+			if err != nil {
+				return err
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			edit, err := bulkEdit(cmd.Context(), f, spaceID, opts)
+			if err != nil {
+				return err
+			}
+			objects, err := bulkObjects(cmd, f, spaceID, args[1:], opts, filters)
+			if err != nil {
+				return err
+			}
+			if len(objects) == 0 {
+				f.Println("No objects match.")
+				return nil
+			}
+
+			if !opts.yes {
+				table := output.NewTable([]string{"OBJECT ID", "NAME", "TYPE"})
+				for _, obj := range objects {
+					table.AddRow([]string{obj.ID, obj.Name, obj.TypeKey})
+				}
+				fmt.Fprint(f.IO.ErrOut, table.String())
+				fmt.Fprintf(f.IO.ErrOut, "\nChanges:\n  %s\n", strings.Join(edit.Describe(), "\n  "))
+			}
+			if err := confirmAction(f, opts.yes, fmt.Sprintf("Apply to %d object(s)?", len(objects))); err != nil {
+				return err
+			}
 
-		anytypeClient := client.GetClient(cfg)
-		resp, err := anytypeClient.Space(spaceID).Object(objectID).Export(ctx, "markdown")
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
+			defer cancel()
+
+			byID := map[string]anytype.Object{}
+			for _, obj := range objects {
+				byID[obj.ID] = obj
+			}
+			var mu sync.Mutex
+			outcomes := map[string]string{}
+			progress := output.NewProgress(f.IO.ErrOut, len(objects))
+			results := anytypecli.ForEachConcurrent(ctx, objectIDs(objects), opts.concurrency, func(ctx context.Context, id string) error {
+				outcome, err := anytypecli.ApplyEdit(ctx, f.Client(), f.API(), spaceID, byID[id], edit)
+				mu.Lock()
+				outcomes[id] = outcome
+				mu.Unlock()
+				return err
+			}, func(anytypecli.BulkResult) {
+				progress.Increment()
+			})
+			progress.Finish()
+
+			report := make([]objectsBulkResult, 0, len(results))
+			for _, result := range results {
+				entry := objectsBulkResult{ID: result.ID, Name: byID[result.ID].Name, Result: outcomes[result.ID]}
+				if result.Err != nil {
+					entry.Result, entry.Error = "failed", result.Err.Error()
+				}
+				report = append(report, entry)
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				if err := f.PrintStructured(report); err != nil {
+					return err
+				}
+			default:
+				table := output.NewTable([]string{"OBJECT ID", "NAME", "RESULT"})
+				for _, entry := range report {
+					result := entry.Result
+					if entry.Error != "" {
+						result += ": " + entry.Error
+					}
+					table.AddRow([]string{entry.ID, entry.Name, result})
+				}
+				f.Print(table.String())
+				f.Printf("\n%d succeeded, %d failed.\n", len(results.Succeeded()), len(results.Failed()))
+			}
+			if failed := results.Failed(); len(failed) > 0 {
+				return &clierrors.PartialFailureError{Message: fmt.Sprintf("%d of %d objects could not be changed", len(failed), len(results))}
+			}
+			return nil
+		},
+	}
+
+	opts.sel.addQueryFlags(cmd, "change")
+	cmd.Flags().BoolVar(&opts.sel.fromStdin, "from-stdin", false, "Read object IDs from standard input, one per line or as NDJSON")
+	cmd.Flags().StringArrayVar(&opts.filters, "filter", nil, "Filter expression the objects must match, e.g. 'status = Done' (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.set, "set", nil, "Property value to set, as key=value; an empty value clears it (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.addTags, "add-tag", nil, "Tag to add to a select or multi-select property, as key=tag (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.removeTags, "remove-tag", nil, "Tag to remove from a select or multi-select property, as key=tag (repeatable)")
+	cmd.Flags().StringVar(&opts.typeKey, "change-type", "", "Type key, ID or name to change the objects to")
+	cmd.Flags().BoolVar(&opts.archive, "archive", false, "Archive the objects, after the other changes")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", 4, "Number of objects changed at a time")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Do not show the objects and ask for confirmation")
+
+	return cmd
+}
+
+// bulkEdit resolves the changes given to the objects bulk command
+func bulkEdit(ctx context.Context, f *Factory, spaceID string, opts *objectsBulkOptions) (anytypecli.ObjectEdit, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	edit := anytypecli.ObjectEdit{Archive: opts.archive}
+	for _, s := range opts.set {
+		assignment, err := anytypecli.ParseAssignment(s)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to export object: %v\n", err)
-			os.Exit(1)
+			return edit, clierrors.Validationf("%v", err)
 		}
-
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp, "", "  ")
+		property, err := anytypecli.AssignProperty(ctx, f.API(), spaceID, assignment, f.Now())
+		if err != nil {
+			return edit, clierrors.Wrap(err, fmt.Sprintf("invalid value for %s", assignment.Key))
+		}
+		edit.Set = append(edit.Set, property)
+	}
+	for _, tags := range []struct {
+		exprs []string
+		dst   *[]anytypecli.TagChange
+	}{{opts.addTags, &edit.AddTags}, {opts.removeTags, &edit.RemoveTags}} {
+		for _, s := range tags.exprs {
+			change, err := anytypecli.ParseTagChange(ctx, f.API(), spaceID, s)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return edit, clierrors.Wrap(err, "invalid tag")
 			}
-			fmt.Println(string(jsonOutput))
-		default:
-			fmt.Println(resp.Markdown)
+			*tags.dst = append(*tags.dst, change)
 		}
-	},
+	}
+	if opts.typeKey != "" {
+		typ, err := f.ResolveType(ctx, spaceID, opts.typeKey)
+		if err != nil {
+			return edit, err
+		}
+		edit.TypeKey = typ
+	}
+	return edit, nil
 }
 
-var (
-	objectName       string
-	objectTypeKey    string
-	objectDesc       string
-	objectIcon       string
-	objectBody       string
-	objectTemplateID string
-)
+// bulkObjects returns the objects selected for the objects bulk command, with their
+// properties loaded
+func bulkObjects(cmd *cobra.Command, f *Factory, spaceID string, ids []string, opts *objectsBulkOptions, filters []anytype.ListFilter) ([]anytype.Object, error) {
+	ctx, cancel := context.WithTimeout(cmd.Context(), time.Minute)
+	defer cancel()
 
-func init() {
-	rootCmd.AddCommand(objectsCmd)
-	objectsCmd.AddCommand(objectsListCmd)
-	objectsCmd.AddCommand(objectsGetCmd)
-	objectsCmd.AddCommand(objectsCreateCmd)
-	objectsCmd.AddCommand(objectsDeleteCmd)
-	objectsCmd.AddCommand(objectsExportCmd)
-
-	// Set up completion functions after config is loaded
-	// This is added to the OnInitialize pipeline
-	cobra.OnInitialize(func() {
-		if cfg != nil {
-			spaceCompletion := spaces.GetSpaceCompletionFunc(cfg)
-			// Add space completion to commands
-			objectsListCmd.ValidArgsFunction = spaceCompletion
-			objectsGetCmd.ValidArgsFunction = spaceCompletion
-			objectsCreateCmd.ValidArgsFunction = spaceCompletion
-			objectsDeleteCmd.ValidArgsFunction = spaceCompletion
-			objectsExportCmd.ValidArgsFunction = spaceCompletion
+	var objects []anytype.Object
+	if len(ids) == 0 && !opts.sel.fromStdin && !opts.sel.hasQuery(cmd) {
+		all, err := anytypecli.AllObjects(ctx, f.API(), spaceID)
+		if err != nil {
+			return nil, clierrors.Wrap(err, "failed to list objects")
 		}
-	})
-
-	// Flags for create command
-	objectsCreateCmd.Flags().StringVar(&objectName, "name", "", "Name for the new object (required)")
-	objectsCreateCmd.Flags().StringVar(&objectTypeKey, "type", "ot-page", "Type key for the object (default: ot-page)")
-	objectsCreateCmd.Flags().StringVar(&objectDesc, "description", "", "Description for the new object")
-	objectsCreateCmd.Flags().StringVar(&objectIcon, "icon", "", "Emoji icon for the object (e.g. '📄')")
-	objectsCreateCmd.Flags().StringVar(&objectBody, "body", "", "Markdown body content for the object")
-	objectsCreateCmd.Flags().StringVar(&objectTemplateID, "template", "", "Template ID to use for creating the object")
-	objectsCreateCmd.MarkFlagRequired("name")
+		for _, obj := range all {
+			if !obj.Archived {
+				objects = append(objects, obj)
+			}
+		}
+	} else {
+		selected, err := opts.sel.objects(ctx, cmd, f, spaceID, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range selected {
+			// Objects given by ID are loaded, search results come with their properties
+			if obj.Name == "" && obj.TypeKey == "" {
+				resp, err := f.Client().Space(spaceID).Object(obj.ID).Get(ctx)
+				if err != nil {
+					return nil, clierrors.Wrap(err, fmt.Sprintf("failed to get object %s", obj.ID))
+				}
+				obj = *resp.Object
+			}
+			objects = append(objects, obj)
+		}
+	}
+
+	return slices.DeleteFunc(objects, func(obj anytype.Object) bool {
+		return !anytypecli.MatchFilters(obj, filters, f.Now())
+	}), nil
 }
@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/internal/testserver"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+	"github.com/epheo/anytype-go"
+)
+
+func TestObjectsCommands(t *testing.T) {
+	runCommandTests(t, []commandTest{
+		{name: "objects_list", args: []string{"objects", "list", "Engineering"}},
+		{name: "objects_get", args: []string{"objects", "get", "Engineering", testserver.ObjectWriteDocs}},
+		{name: "objects_create", args: []string{"objects", "create", "Engineering", "--name", "Plan", "--type", "ot-page", "--body", "# Plan"}},
+		{name: "objects_create_template", args: []string{"objects", "create", "Engineering", "--type", "Page", "--template", "Meeting Notes",
+			"--name", "Meeting {{date}}", "--var", "attendees=Alice, Bob"}, setup: addMeetingNotes},
+		{name: "objects_create_template_undefined", args: []string{"objects", "create", "Engineering", "--template", "meeting notes"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}, setup: addMeetingNotes},
+		{name: "objects_create_invalid_var", args: []string{"objects", "create", "Engineering", "--name", "X", "--var", "week"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "objects_delete", args: []string{"objects", "delete", "Engineering", testserver.ObjectFixBug}},
+		{name: "objects_export", args: []string{"objects", "export", "Engineering", testserver.ObjectRoadmap},
+			formats: []string{output.FormatTable, output.FormatJSON}},
+		{name: "objects_import", args: []string{"objects", "import", "Engineering", "testdata/import/retro.md"}},
+		{name: "objects_import_untitled", args: []string{"objects", "import", "Engineering", "testdata/import/untitled.md"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "objects_list_archived", args: []string{"objects", "list", "Engineering Archive", "--archived"}},
+		{name: "objects_archive", args: []string{"objects", "archive", "Engineering", testserver.ObjectFixBug, testserver.ObjectWriteDocs, "--yes"},
+			formats: []string{output.FormatTable}},
+		{name: "objects_archive_unconfirmed", args: []string{"objects", "archive", "Engineering", testserver.ObjectFixBug},
+			wantCode: clierrors.ExitCancelled, formats: []string{output.FormatTable}},
+		{name: "objects_restore", args: []string{"objects", "restore", "Engineering Archive", testserver.ObjectOldSpec}},
+		{name: "objects_restore_not_archived", args: []string{"objects", "restore", "Engineering", testserver.ObjectRoadmap},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "objects_duplicate", args: []string{"objects", "duplicate", "Engineering", testserver.ObjectWriteDocs, "--name", "Write more docs",
+			"--set", "status=Open", "--set", "due_date=2026-12-01", "--add-to-lists"}},
+		{name: "objects_duplicate_unknown_tag", args: []string{"objects", "duplicate", "Engineering", testserver.ObjectWriteDocs, "--set", "status=Planned"},
+			wantCode: clierrors.ExitNotFound, formats: []string{output.FormatTable}},
+		{name: "objects_duplicate_invalid_set", args: []string{"objects", "duplicate", "Engineering", testserver.ObjectWriteDocs, "--set", "status"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "objects_copy", args: []string{"objects", "copy", "Engineering", testserver.ObjectWriteDocs, "Personal", "--create-missing"}},
+		{name: "objects_copy_missing_type", args: []string{"objects", "copy", "Engineering", testserver.ObjectWriteDocs, "Personal"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "objects_move_same_space", args: []string{"objects", "move", "Engineering", testserver.ObjectWriteDocs, "Engineering"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "objects_move_recursive_unconfirmed", args: []string{"objects", "move", "Engineering", testserver.ObjectRoadmap, "Personal", "-r"},
+			wantCode: clierrors.ExitCancelled, formats: []string{output.FormatTable}, setup: linkRoadmapToDocs},
+		{name: "objects_move_depth_without_recursive", args: []string{"objects", "move", "Engineering", testserver.ObjectRoadmap, "Personal", "--depth", "1"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "objects_bulk", args: []string{"objects", "bulk", "Engineering", "--types", "ot-task", "--set", "status=Done",
+			"--add-tag", "status=Done", "--concurrency", "2", "--yes"}},
+		{name: "objects_bulk_filter_archive", args: []string{"objects", "bulk", "Engineering", "--filter", "status = Done", "--archive", "--yes"},
+			formats: []string{output.FormatTable}},
+		{name: "objects_bulk_change_type", args: []string{"objects", "bulk", "Engineering", testserver.ObjectFixBug, "--change-type", "Page", "--yes"},
+			formats: []string{output.FormatTable}},
+		{name: "objects_bulk_unconfirmed", args: []string{"objects", "bulk", "Engineering", "--types", "ot-task", "--remove-tag", "status=Done"},
+			wantCode: clierrors.ExitCancelled, formats: []string{output.FormatTable}},
+		{name: "objects_bulk_no_action", args: []string{"objects", "bulk", "Engineering", testserver.ObjectFixBug},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "objects_bulk_no_selection", args: []string{"objects", "bulk", "Engineering", "--archive"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "objects_bulk_partial_failure", args: []string{"objects", "bulk", "Engineering", testserver.ObjectFixBug, testserver.ObjectRoadmap,
+			"--add-tag", "labels=Urgent", "--yes"}, wantCode: clierrors.ExitPartialFailure, setup: addStaleLabel},
+		{name: "objects_bulk_stdin_unconfirmed", args: []string{"objects", "bulk", "Engineering", "--from-stdin", "--archive"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+	})
+}
+
+// linkRoadmapToDocs makes the roadmap link to the Write docs task, in its body and links property
+func linkRoadmapToDocs(srv *testserver.Server) {
+	for _, obj := range srv.Objects[testserver.SpaceEngineering] {
+		if obj.ID == testserver.ObjectRoadmap {
+			obj.Markdown += "\nNext: [Write docs](anytype://object?objectId=" + testserver.ObjectWriteDocs + ")\n"
+			obj.Properties = append(obj.Properties, anytype.Property{Key: "links", Name: "Links", Format: "objects", Objects: []string{testserver.ObjectWriteDocs}})
+		}
+	}
+}
+
+func TestObjectsMoveRecursive(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	srv.Lock()
+	linkRoadmapToDocs(srv)
+	srv.Unlock()
+
+	stdout, stderr, code := runCLI(t, srv, "objects", "move", "Engineering", testserver.ObjectRoadmap, "Personal", "--recursive", "--create-missing", "--yes")
+	if code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Moved 2 object(s).") {
+		t.Errorf("stdout = %q, want the roadmap and the linked task moved", stdout)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	var roadmap, docs *anytype.Object
+	for _, obj := range srv.Objects[testserver.SpacePersonal] {
+		switch obj.Name {
+		case "Roadmap":
+			roadmap = obj
+		case "Write docs":
+			docs = obj
+		}
+	}
+	if roadmap == nil || docs == nil {
+		t.Fatal("the roadmap and the task were not copied to Personal")
+	}
+	if !strings.Contains(roadmap.Markdown, "objectId="+docs.ID+")") {
+		t.Errorf("roadmap body = %q, want a link to the copied task %s", roadmap.Markdown, docs.ID)
+	}
+	for _, obj := range srv.Objects[testserver.SpaceEngineering] {
+		if (obj.ID == testserver.ObjectRoadmap || obj.ID == testserver.ObjectWriteDocs) && !obj.Archived {
+			t.Errorf("original %s was not archived", obj.ID)
+		}
+	}
+}
+
+func TestObjectsMoveRecursiveUnconfirmed(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	srv.Lock()
+	linkRoadmapToDocs(srv)
+	requests := len(srv.Requests)
+	srv.Unlock()
+
+	_, stderr, code := runCLI(t, srv, "objects", "move", "Engineering", testserver.ObjectRoadmap, "Personal", "--recursive", "--create-missing")
+	if code != clierrors.ExitCancelled {
+		t.Fatalf("exit code = %d, want %d, stderr: %s", code, clierrors.ExitCancelled, stderr)
+	}
+	if !strings.Contains(stderr, testserver.ObjectWriteDocs) || !strings.Contains(stderr, "Copy 2 object(s) to Personal and archive the originals?") {
+		t.Errorf("stderr = %q, want the linked task listed for confirmation", stderr)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	for _, req := range srv.Requests[requests:] {
+		if req.Method != "GET" {
+			t.Errorf("unconfirmed move sent %s %s", req.Method, req.Path)
+		}
+	}
+}
+
+func TestObjectsMoveDepth(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	// The task the roadmap links to links to another task, two links away
+	srv.Lock()
+	linkRoadmapToDocs(srv)
+	for _, obj := range srv.Objects[testserver.SpaceEngineering] {
+		if obj.ID == testserver.ObjectWriteDocs {
+			obj.Properties = append(obj.Properties, anytype.Property{Key: "links", Name: "Links", Format: "objects", Objects: []string{testserver.ObjectFixBug}})
+		}
+	}
+	srv.Unlock()
+
+	stdout, stderr, code := runCLI(t, srv, "objects", "move", "Engineering", testserver.ObjectRoadmap, "Personal",
+		"--recursive", "--depth", "1", "--create-missing", "--yes")
+	if code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, "Moved 2 object(s).") || strings.Contains(stdout, testserver.ObjectFixBug) {
+		t.Errorf("stdout = %q, want the roadmap and the task it links to only", stdout)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	for _, obj := range srv.Objects[testserver.SpaceEngineering] {
+		if obj.ID == testserver.ObjectFixBug && obj.Archived {
+			t.Error("the task two links away was archived")
+		}
+	}
+}
+
+func TestObjectsImportFromStdin(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	f, _, stderr := newTestFactory()
+	f.IO.In = strings.NewReader("Standup summary")
+	args := []string{"--base-url", srv.URL, "objects", "import", testserver.SpaceEngineering, "-", "--name", "Standup"}
+	if code := Run(context.Background(), f, args); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	objects := srv.Objects[testserver.SpaceEngineering]
+	last := objects[len(objects)-1]
+	if last.Name != "Standup" || last.Markdown != "Standup summary" {
+		t.Errorf("imported object = %q with body %q", last.Name, last.Markdown)
+	}
+}
+
+func TestObjectsDeleteArchivesObject(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	if _, stderr, code := runCLI(t, srv, "objects", "delete", testserver.SpaceEngineering, testserver.ObjectFixBug); code != 0 {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	for _, obj := range srv.Objects[testserver.SpaceEngineering] {
+		if obj.ID == testserver.ObjectFixBug && !obj.Archived {
+			t.Error("deleted object is not archived")
+		}
+	}
+}
+
+func TestObjectsRestoreRefusesRestoredObject(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	restore := func(args ...string) (string, int) {
+		_, stderr, code := runCLI(t, srv, append([]string{"objects", "restore", testserver.SpaceEngineeringArchive, testserver.ObjectOldSpec}, args...)...)
+		return stderr, code
+	}
+	if stderr, code := restore(); code != clierrors.ExitOK {
+		t.Fatalf("first restore: exit code = %d, stderr: %s", code, stderr)
+	}
+	stderr, code := restore()
+	if code != clierrors.ExitValidation {
+		t.Fatalf("second restore: exit code = %d, want %d", code, clierrors.ExitValidation)
+	}
+	if want := "was already restored as obj-new-2, run again with --force"; !strings.Contains(stderr, want) {
+		t.Errorf("second restore: stderr = %q, want %q", stderr, want)
+	}
+	if stderr, code := restore("--force"); code != clierrors.ExitOK {
+		t.Fatalf("forced restore: exit code = %d, stderr: %s", code, stderr)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	var copies int
+	for _, obj := range srv.Objects[testserver.SpaceEngineeringArchive] {
+		if anytypecli.PropertyText(*obj, anytypecli.RestoredFromProperty) == testserver.ObjectOldSpec {
+			copies++
+		}
+	}
+	if copies != 2 {
+		t.Errorf("got %d objects restored from %s, want 2", copies, testserver.ObjectOldSpec)
+	}
+}
+
+// addMeetingNotes adds a page template whose body has placeholders, and the profile of the
+// current user that {{me}} names
+func addMeetingNotes(srv *testserver.Server) {
+	space := testserver.SpaceEngineering
+	srv.Templates[space]["ot-page"] = append(srv.Templates[space]["ot-page"], &anytype.Template{
+		ID: testserver.TemplateMeetingNotes, Name: "Meeting Notes", Icon: &anytype.Icon{Format: anytype.IconFormatEmoji, Emoji: "📝"},
+	})
+	srv.TemplateBodies[testserver.TemplateMeetingNotes] = "Meeting of {{date}} (week {{week}}), notes by {{me}}.\n\n**Attendees:** {{attendees}}\n"
+	srv.Profiles[space] = &anytype.Object{ID: "obj-profile", Name: "Alice", SpaceID: space, TypeKey: "ot-profile", Layout: "profile"}
+}
+
+func TestObjectsCreateFromTemplateWithoutPlaceholders(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	if _, stderr, code := runCLI(t, srv, "objects", "create", testserver.SpaceEngineering, "--type", "Task", "--template", "Default Task"); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	create := srv.Requests[len(srv.Requests)-1]
+	for _, want := range []string{`"template_id":"` + testserver.TemplateTaskDefault + `"`, `"Name":"Default Task"`, `"type_key":"ot-task"`} {
+		if !strings.Contains(create.Body, want) {
+			t.Errorf("create request %s %s\nwant %s", create.Path, create.Body, want)
+		}
+	}
+}
+
+// addStaleLabel adds a multi-select labels property with an Urgent tag, and sets a tag the
+// server no longer knows on Fix bug so that updating its labels fails
+func addStaleLabel(srv *testserver.Server) {
+	space := testserver.SpaceEngineering
+	srv.Properties[space] = append(srv.Properties[space],
+		&testserver.Property{Object: "property", ID: "prop-labels", Key: "labels", Name: "Labels", Format: "multi_select"})
+	srv.Tags[space]["prop-labels"] = []*anytype.Tag{{Object: "tag", ID: "tag-urgent", Key: "urgent", Name: "Urgent", Color: "red"}}
+	for _, obj := range srv.Objects[space] {
+		if obj.ID == testserver.ObjectFixBug {
+			obj.Properties = append(obj.Properties, anytype.Property{Key: "labels", Name: "Labels", Format: "multi_select",
+				MultiSelect: []anytype.Tag{{ID: "tag-stale", Name: "Stale"}}})
+		}
+	}
+}
+
+func TestObjectsBulkFromStdin(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	f, stdout, stderr := newTestFactory()
+	f.IO.In = strings.NewReader(testserver.ObjectFixBug + "\n")
+	args := []string{"--base-url", srv.URL, "objects", "bulk", testserver.SpaceEngineering, "--from-stdin", "--archive", "--yes"}
+	if code := Run(context.Background(), f, args); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	if want := "1 succeeded, 0 failed."; !strings.Contains(stdout.String(), want) {
+		t.Errorf("stdout = %q, want %q", stdout, want)
+	}
+}
+
+func TestObjectsBulkSetsProperties(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	args := []string{"objects", "bulk", testserver.SpaceEngineering, "--types", "ot-task", "--set", "status=Done", "--yes"}
+	if _, stderr, code := runCLI(t, srv, args...); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	for _, obj := range srv.Objects[testserver.SpaceEngineering] {
+		if obj.TypeKey != "ot-task" {
+			continue
+		}
+		for _, property := range obj.Properties {
+			if property.Key == "status" && (property.Select == nil || property.Select.ID != testserver.TagDone) {
+				t.Errorf("status of %s = %v, want Done", obj.ID, property.Select)
+			}
+		}
+	}
+	updates := 0
+	for _, req := range srv.Requests {
+		if req.Method == "PATCH" {
+			updates++
+		}
+	}
+	// Fix bug is already done and is left unchanged
+	if updates != 1 {
+		t.Errorf("%d objects updated, want 1", updates)
+	}
+}
@@ -0,0 +1,573 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+	"github.com/epheo/anytype-go"
+	"github.com/spf13/cobra"
+)
+
+// newPropertiesCmd creates the properties command
+func newPropertiesCmd(f *Factory) *cobra.Command {
+	propertiesCmd := &cobra.Command{
+		Use:     "properties",
+		Aliases: []string{"relations"},
+		Short:   "Manage property definitions",
+		Long: `List, create, update and delete the property (relation) definitions of an
+Anytype space, and manage the tags of select and multi-select properties.`,
+	}
+
+	propertiesCmd.AddCommand(
+		newPropertiesListCmd(f),
+		newPropertiesCreateCmd(f),
+		newPropertiesUpdateCmd(f),
+		newPropertiesDeleteCmd(f),
+		newPropertiesTagsCmd(f),
+	)
+
+	return propertiesCmd
+}
+
+// propertyUsage is a property definition with the names of the types using it
+type propertyUsage struct {
+	anytypecli.Property `yaml:",inline"`
+	UsedBy              []string `json:"used_by" yaml:"used_by"`
+}
+
+// newPropertiesListCmd creates the properties list command
+func newPropertiesListCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [spaceID|spaceName]",
+		Short: "List all property definitions in a space",
+		Long:  `List the property definitions of a space, with the object types that use each of them.`,
+		Args:  exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			properties, err := anytypecli.Collect(f.API().Properties(ctx, spaceID))
+			if err != nil {
+				return clierrors.Wrap(err, "failed to list properties")
+			}
+			types, err := anytypecli.Collect(f.API().Types(ctx, spaceID))
+			if err != nil {
+				return clierrors.Wrap(err, "failed to list object types")
+			}
+
+			usedBy := map[string][]string{}
+			for _, typ := range types {
+				for _, def := range typ.PropertyDefinitions {
+					usedBy[def.Key] = append(usedBy[def.Key], typ.Name)
+				}
+			}
+			usages := make([]propertyUsage, 0, len(properties))
+			for _, property := range properties {
+				usage := propertyUsage{Property: property, UsedBy: usedBy[property.Key]}
+				if usage.UsedBy == nil {
+					usage.UsedBy = []string{}
+				}
+				usages = append(usages, usage)
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(usages)
+			default:
+				// Table format with dynamic column widths
+				table := output.NewTable([]string{"KEY", "NAME", "FORMAT", "USED BY"})
+				for _, usage := range usages {
+					table.AddRow([]string{usage.Key, usage.Name, usage.Format, strings.Join(usage.UsedBy, ", ")})
+				}
+				f.Print(table.String())
+				f.Printf("\nTotal properties: %d\n", len(usages))
+			}
+			return nil
+		},
+	}
+}
+
+// propertyDetails is a property definition with its tags
+type propertyDetails struct {
+	anytypecli.Property `yaml:",inline"`
+	Tags                []anytype.Tag `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// printProperty writes the details of a property definition and its tags in the selected output format
+func printProperty(f *Factory, property *anytypecli.Property, tags []anytype.Tag) error {
+	switch f.OutputFormat {
+	case output.FormatJSON, output.FormatYAML:
+		return f.PrintStructured(propertyDetails{Property: *property, Tags: tags})
+	default:
+		// Detailed output
+		f.Println("PROPERTY DETAILS")
+		f.Println("----------------")
+		f.Printf("ID: %s\n", property.ID)
+		f.Printf("Key: %s\n", property.Key)
+		f.Printf("Name: %s\n", property.Name)
+		f.Printf("Format: %s\n", property.Format)
+
+		if len(tags) > 0 {
+			f.Println("\nTAGS")
+			f.Println("----")
+			printTags(f, tags)
+		}
+	}
+	return nil
+}
+
+// printTags writes a table of tags
+func printTags(f *Factory, tags []anytype.Tag) {
+	table := output.NewTable([]string{"TAG ID", "KEY", "NAME", "COLOR"})
+	for _, tag := range tags {
+		table.AddRow([]string{tag.ID, tag.Key, tag.Name, tag.Color})
+	}
+	f.Print(table.String())
+}
+
+// propertiesCreateOptions holds the flags of the properties create command
+type propertiesCreateOptions struct {
+	name   string
+	key    string
+	format string
+	tags   []string
+}
+
+// newPropertiesCreateCmd creates the properties create command
+func newPropertiesCreateCmd(f *Factory) *cobra.Command {
+	opts := &propertiesCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create [spaceID|spaceName]",
+		Short: "Create a new property definition",
+		Long: fmt.Sprintf(`Create a new property definition in the specified Anytype space.
+
+Select and multi-select properties can be created with their tags, given as
+Name or Name:color, for example:
+  anytype-cli properties create Engineering --name Status --format select \
+    --tag "Open:teal" --tag "Done:grey"
+
+Tag colors: %s`, strings.Join(anytypecli.TagColors, ", ")),
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.name == "" {
+				return clierrors.Validationf("property name is required")
+			}
+			if !anytypecli.ValidPropertyFormat(opts.format) {
+				return clierrors.Validationf("invalid format '%s' (expected one of: %s)",
+					opts.format, strings.Join(anytypecli.PropertyFormats, ", "))
+			}
+			if len(opts.tags) > 0 && !anytypecli.HasTags(opts.format) {
+				return clierrors.Validationf("--tag is only supported for select and multi_select properties, not %s", opts.format)
+			}
+			tags, err := parseTags(opts.tags)
+			if err != nil {
+				return err
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			createReq := anytypecli.CreatePropertyRequest{
+				Key:    opts.key,
+				Name:   opts.name,
+				Format: opts.format,
+				Tags:   tags,
+			}
+
+			property, err := f.API().CreateProperty(ctx, spaceID, createReq)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to create property")
+			}
+
+			var created []anytype.Tag
+			if anytypecli.HasTags(property.Format) {
+				created, err = anytypecli.Collect(f.API().Tags(ctx, spaceID, property.ID))
+				if err != nil {
+					return clierrors.Wrap(err, "failed to list tags")
+				}
+			}
+
+			return printProperty(f, property, created)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name for the new property (required)")
+	cmd.Flags().StringVar(&opts.key, "key", "", "Key for the new property (default: generated by Anytype)")
+	cmd.Flags().StringVar(&opts.format, "format", "", fmt.Sprintf("Format of the property (%s)", strings.Join(anytypecli.PropertyFormats, ", ")))
+	cmd.Flags().StringArrayVar(&opts.tags, "tag", nil, "Tag of a select or multi_select property, as Name[:color] (repeatable)")
+	cmd.MarkFlagRequired("name")
+	cmd.MarkFlagRequired("format")
+
+	return cmd
+}
+
+// propertiesUpdateOptions holds the flags of the properties update command
+type propertiesUpdateOptions struct {
+	name string
+	key  string
+}
+
+// newPropertiesUpdateCmd creates the properties update command
+func newPropertiesUpdateCmd(f *Factory) *cobra.Command {
+	opts := &propertiesUpdateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "update [spaceID|spaceName] [propertyKey|propertyName]",
+		Short: "Update a property definition",
+		Long: `Rename a property definition or change its key. Only the given flags are changed.
+The format of a property cannot be changed.`,
+		Args: exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.name == "" && opts.key == "" {
+				return clierrors.Validationf("nothing to update, set at least one of --name or --key")
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			property, err := f.ResolveProperty(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			updateReq := anytypecli.UpdatePropertyRequest{
+				Name: opts.name,
+				Key:  opts.key,
+			}
+
+			updated, err := f.API().UpdateProperty(ctx, spaceID, property.ID, updateReq)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to update property")
+			}
+
+			return printProperty(f, updated, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "New name for the property")
+	cmd.Flags().StringVar(&opts.key, "key", "", "New key for the property")
+
+	return cmd
+}
+
+// newPropertiesDeleteCmd creates the properties delete command
+func newPropertiesDeleteCmd(f *Factory) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete [spaceID|spaceName] [propertyKey|propertyName]",
+		Short: "Delete a property definition",
+		Long: `Delete a property definition, and the tags of select and multi-select properties.
+The values of the property are dropped from every object that has one.
+
+The property is deleted after confirmation unless --yes is given. Declining the
+confirmation, or giving no answer, exits with code 9.`,
+		Args: exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			property, err := f.ResolveProperty(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			question := fmt.Sprintf("Delete property '%s' (Key: %s) and its values on every object?", property.Name, property.Key)
+			if err := confirmAction(f, yes, question); err != nil {
+				return err
+			}
+			deleted, err := f.API().DeleteProperty(ctx, spaceID, property.ID)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to delete property")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(deleted)
+			default:
+				f.Printf("Property '%s' (Key: %s) deleted successfully.\n", deleted.Name, deleted.Key)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+
+	return cmd
+}
+
+// newPropertiesTagsCmd creates the properties tags command
+func newPropertiesTagsCmd(f *Factory) *cobra.Command {
+	tagsCmd := &cobra.Command{
+		Use:   "tags",
+		Short: "Manage the tags of select and multi-select properties",
+		Long:  `List, add, rename and remove the tags offered by a select or multi-select property.`,
+	}
+
+	tagsCmd.AddCommand(
+		newPropertiesTagsListCmd(f),
+		newPropertiesTagsAddCmd(f),
+		newPropertiesTagsRenameCmd(f),
+		newPropertiesTagsRemoveCmd(f),
+	)
+
+	return tagsCmd
+}
+
+// resolveTagProperty resolves the space and property arguments of the tags commands,
+// and checks that the property takes its values from tags
+func resolveTagProperty(cmd *cobra.Command, f *Factory, spaceIdOrName, propertyRef string) (string, *anytypecli.Property, error) {
+	spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+	if err != nil {
+		return "", nil, err
+	}
+	property, err := f.ResolveProperty(cmd.Context(), spaceID, propertyRef)
+	if err != nil {
+		return "", nil, err
+	}
+	if !anytypecli.HasTags(property.Format) {
+		return "", nil, clierrors.Validationf("property '%s' has format %s, only select and multi_select properties have tags",
+			property.Name, property.Format)
+	}
+	return spaceID, property, nil
+}
+
+// newPropertiesTagsListCmd creates the properties tags list command
+func newPropertiesTagsListCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [spaceID|spaceName] [propertyKey|propertyName]",
+		Short: "List the tags of a property",
+		Long:  `List the tags offered by a select or multi-select property.`,
+		Args:  exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceID, property, err := resolveTagProperty(cmd, f, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			tags, err := anytypecli.Collect(f.API().Tags(ctx, spaceID, property.ID))
+			if err != nil {
+				return clierrors.Wrap(err, "failed to list tags")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(tags)
+			default:
+				printTags(f, tags)
+				f.Printf("\nTotal tags: %d\n", len(tags))
+			}
+			return nil
+		},
+	}
+}
+
+// newPropertiesTagsAddCmd creates the properties tags add command
+func newPropertiesTagsAddCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add [spaceID|spaceName] [propertyKey|propertyName] [Name[:color]...]",
+		Short: "Add tags to a property",
+		Long: fmt.Sprintf(`Add one or more tags to a select or multi-select property. Each tag is given as
+Name or Name:color, the color defaulting to %s.
+
+Tag colors: %s`, anytypecli.DefaultTagColor, strings.Join(anytypecli.TagColors, ", ")),
+		Args: minimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tags, err := parseTags(args[2:])
+			if err != nil {
+				return err
+			}
+
+			spaceID, property, err := resolveTagProperty(cmd, f, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			added := []anytype.Tag{}
+			for _, tagReq := range tags {
+				tag, err := f.API().CreateTag(ctx, spaceID, property.ID, tagReq)
+				if err != nil {
+					return clierrors.Wrap(err, fmt.Sprintf("failed to add tag '%s'", tagReq.Name))
+				}
+				added = append(added, *tag)
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(added)
+			default:
+				for _, tag := range added {
+					f.Printf("Tag '%s' (ID: %s) added to property '%s'.\n", tag.Name, tag.ID, property.Name)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// propertiesTagsRenameOptions holds the flags of the properties tags rename command
+type propertiesTagsRenameOptions struct {
+	color string
+}
+
+// newPropertiesTagsRenameCmd creates the properties tags rename command
+func newPropertiesTagsRenameCmd(f *Factory) *cobra.Command {
+	opts := &propertiesTagsRenameOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "rename [spaceID|spaceName] [propertyKey|propertyName] [tagID|tagName] [newName]",
+		Short: "Rename a tag of a property",
+		Long: `Rename a tag of a select or multi-select property, and optionally change its color.
+Objects using the tag keep it under its new name.`,
+		Args: exactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.color != "" && !anytypecli.ValidTagColor(opts.color) {
+				return clierrors.Validationf("invalid color '%s' (expected one of: %s)",
+					opts.color, strings.Join(anytypecli.TagColors, ", "))
+			}
+
+			spaceID, property, err := resolveTagProperty(cmd, f, args[0], args[1])
+			if err != nil {
+				return err
+			}
+			tag, err := f.ResolveTag(cmd.Context(), spaceID, property.ID, args[2])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			updateReq := anytypecli.UpdateTagRequest{
+				Name:  args[3],
+				Color: opts.color,
+			}
+
+			updated, err := f.API().UpdateTag(ctx, spaceID, property.ID, tag.ID, updateReq)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to rename tag")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(updated)
+			default:
+				f.Printf("Tag '%s' renamed to '%s' (color: %s).\n", tag.Name, updated.Name, updated.Color)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.color, "color", "", "New color for the tag")
+
+	return cmd
+}
+
+// newPropertiesTagsRemoveCmd creates the properties tags remove command
+func newPropertiesTagsRemoveCmd(f *Factory) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "remove [spaceID|spaceName] [propertyKey|propertyName] [tagID|tagName...]",
+		Short: "Remove tags from a property",
+		Long: `Remove one or more tags from a select or multi-select property. The objects that
+have a removed tag lose it.
+
+The tags are removed after confirmation unless --yes is given. Declining the
+confirmation, or giving no answer, exits with code 9.`,
+		Args: minimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceID, property, err := resolveTagProperty(cmd, f, args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			var tags []*anytype.Tag
+			var names []string
+			for _, ref := range args[2:] {
+				tag, err := f.ResolveTag(cmd.Context(), spaceID, property.ID, ref)
+				if err != nil {
+					return err
+				}
+				tags = append(tags, tag)
+				names = append(names, fmt.Sprintf("'%s'", tag.Name))
+			}
+			question := fmt.Sprintf("Remove tag(s) %s from property '%s' and from every object?", strings.Join(names, ", "), property.Name)
+			if err := confirmAction(f, yes, question); err != nil {
+				return err
+			}
+
+			removed := []anytype.Tag{}
+			for _, tag := range tags {
+				ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+				deleted, err := f.API().DeleteTag(ctx, spaceID, property.ID, tag.ID)
+				cancel()
+				if err != nil {
+					return clierrors.Wrap(err, fmt.Sprintf("failed to remove tag '%s'", tag.Name))
+				}
+				removed = append(removed, *deleted)
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(removed)
+			default:
+				for _, tag := range removed {
+					f.Printf("Tag '%s' (ID: %s) removed from property '%s'.\n", tag.Name, tag.ID, property.Name)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+
+	return cmd
+}
+
+// parseTags parses Name[:color] tag values
+func parseTags(values []string) ([]anytypecli.CreateTagRequest, error) {
+	var tags []anytypecli.CreateTagRequest
+	for _, value := range values {
+		tag, err := anytypecli.ParseTag(value)
+		if err != nil {
+			return nil, clierrors.Validationf("%v", err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
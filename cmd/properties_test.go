@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/internal/testserver"
+)
+
+func TestPropertiesCommands(t *testing.T) {
+	runCommandTests(t, []commandTest{
+		{name: "properties_list", args: []string{"properties", "list", "Engineering"}},
+		{name: "properties_create", args: []string{"properties", "create", "Engineering", "--name", "Priority", "--key", "priority",
+			"--format", "select", "--tag", "High:red", "--tag", "Low"}},
+		{name: "properties_update", args: []string{"properties", "update", "Engineering", "due_date", "--name", "Deadline"}},
+		{name: "properties_delete", args: []string{"properties", "delete", "Engineering", "Done", "--yes"}},
+		{name: "properties_delete_unconfirmed", args: []string{"properties", "delete", "Engineering", "Done"},
+			wantCode: clierrors.ExitCancelled, formats: []string{output.FormatTable}},
+		{name: "properties_tags_list", args: []string{"properties", "tags", "list", "Engineering", "status"}},
+		{name: "properties_tags_add", args: []string{"properties", "tags", "add", "Engineering", "status", "Blocked:red", "Later"}},
+		{name: "properties_tags_rename", args: []string{"properties", "tags", "rename", "Engineering", "status", "In Progress", "Doing",
+			"--color", "blue"}},
+		{name: "properties_tags_remove", args: []string{"properties", "tags", "remove", "Engineering", "Status", "open", testserver.TagDone, "-y"}},
+		{name: "properties_tags_remove_unconfirmed", args: []string{"properties", "tags", "remove", "Engineering", "Status", "open"},
+			wantCode: clierrors.ExitCancelled, formats: []string{output.FormatTable}},
+		{name: "properties_create_invalid_format", args: []string{"properties", "create", "Engineering", "--name", "X", "--format", "bigint"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "properties_create_tags_not_select", args: []string{"properties", "create", "Engineering", "--name", "X", "--format", "text",
+			"--tag", "A"}, wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "properties_create_invalid_color", args: []string{"properties", "create", "Engineering", "--name", "X", "--format", "select",
+			"--tag", "Open:green"}, wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "properties_tags_not_select", args: []string{"properties", "tags", "list", "Engineering", "due_date"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatJSON}},
+		{name: "properties_update_not_found", args: []string{"properties", "update", "Engineering", "prop-missing", "--name", "X"},
+			wantCode: clierrors.ExitNotFound, formats: []string{output.FormatTable}},
+	})
+}
+
+func TestPropertiesTagsRemoveUpdatesList(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	if _, stderr, code := runCLI(t, srv, "properties", "tags", "remove", testserver.SpaceEngineering, testserver.PropertyStatus, "Done", "--yes"); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	stdout, _, _ := runCLI(t, srv, "properties", "tags", "list", testserver.SpaceEngineering, testserver.PropertyStatus)
+	if want := "Total tags: 2"; !strings.Contains(stdout, want) {
+		t.Errorf("tags list after remove:\n%s\nwant %q", stdout, want)
+	}
+}
@@ -1,83 +1,170 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
-	"github.com/epheo/anytype-cli/internal/auth"
-	"github.com/epheo/anytype-cli/internal/config"
+	"github.com/epheo/anytype-cli/internal/clierrors"
 	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/internal/spaces"
 	"github.com/spf13/cobra"
 )
 
-var (
-	cfg          *config.Config
-	cfgFile      string
-	baseURL      string
-	verbose      bool
-	outputFormat string
-)
+// NewRootCmd builds the base command and all its subcommands around the given Factory
+func NewRootCmd(f *Factory) *cobra.Command {
+	var cfgFile string
+
+	rootCmd := &cobra.Command{
+		Use:   "anytype-cli",
+		Short: "A comprehensive CLI for interacting with Anytype",
+		Long: `anytype-cli is a command line tool for interacting with Anytype
 
-// rootCmd represents the base command when called without any subcommands
-var rootCmd = &cobra.Command{
-	Use:   "anytype-cli",
-	Short: "A comprehensive CLI for interacting with Anytype",
-	Long: `anytype-cli is a command line tool for interacting with Anytype
-	
 This CLI allows you to manage spaces, objects, and perform searches in Anytype,
 all from your terminal using the Anytype-Go SDK.`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		// Skip auth check for these commands
-		if cmd.Name() == "auth" || cmd.Name() == "version" || cmd.Name() == "help" {
-			return
-		}
+		// Errors are printed by Run; the usage text only accompanies argument
+		// and flag errors, see usageError
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := f.Setup(); err != nil {
+				return err
+			}
 
-		// Parent command check - if this is a parent command, skip the auth check
-		// as the actual subcommand will do the check
-		if cmd.HasSubCommands() && len(args) == 0 {
-			return
-		}
+			switch f.OutputFormat {
+			case output.FormatTable, output.FormatJSON, output.FormatYAML:
+			default:
+				return clierrors.Validationf("invalid output format '%s' (expected %s, %s or %s)",
+					f.OutputFormat, output.FormatTable, output.FormatJSON, output.FormatYAML)
+			}
 
-		// Check if authenticated (except for auth command)
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("Error: You are not authenticated. Run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
-	},
-}
+			// Skip auth check for these commands
+			if cmd.Name() == "auth" || cmd.Name() == "version" || cmd.Name() == "help" || cmd.Name() == "completion" {
+				return nil
+			}
 
-// Execute adds all child commands to the root command and sets flags appropriately.
-func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+			// Parent command check - if this is a parent command, skip the auth check
+			// as the actual subcommand will do the check
+			if cmd.HasSubCommands() && len(args) == 0 {
+				return nil
+			}
+
+			// Check if authenticated (except for auth command)
+			if !f.Authenticated() {
+				return &clierrors.NotAuthenticatedError{}
+			}
+			return nil
+		},
 	}
-}
 
-func init() {
-	cobra.OnInitialize(initConfig)
+	rootCmd.SetIn(f.IO.In)
+	rootCmd.SetOut(f.IO.Out)
+	rootCmd.SetErr(f.IO.ErrOut)
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.anytype-cli/config.yaml)")
-	rootCmd.PersistentFlags().StringVar(&baseURL, "base-url", "", "Anytype API base URL (default is http://localhost:31009)")
-	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose output")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table",
+	rootCmd.PersistentFlags().StringVar(&f.BaseURL, "base-url", "", "Anytype API base URL (default is http://localhost:31009)")
+	rootCmd.PersistentFlags().BoolVarP(&f.Verbose, "verbose", "v", false, "enable verbose output (log each API call to stderr)")
+	rootCmd.PersistentFlags().BoolVar(&f.DebugHTTP, "debug-http", false, "dump full HTTP requests and responses to stderr (app key redacted)")
+	rootCmd.PersistentFlags().StringVarP(&f.OutputFormat, "output", "o", "table",
 		fmt.Sprintf("output format (%s, %s, %s)", output.FormatTable, output.FormatJSON, output.FormatYAML))
+
+	rootCmd.SetFlagErrorFunc(usageError)
+
+	rootCmd.AddCommand(
+		newAuthCmd(f),
+		newCompletionCmd(f),
+		newListsCmd(f),
+		newMembersCmd(f),
+		newObjectsCmd(f),
+		newPropertiesCmd(f),
+		newSchemaCmd(f),
+		newSearchCmd(f),
+		newSpacesCmd(f),
+		newTemplatesCmd(f),
+		newTrashCmd(f),
+		newTypesCmd(f),
+		newVersionCmd(f),
+	)
+
+	return rootCmd
 }
 
-// initConfig reads in config file and ENV variables if set
-func initConfig() {
-	var err error
+// Run executes the CLI with the given arguments and returns the process exit code.
+// Errors are printed to the factory's error stream, as a JSON object with -o json.
+func Run(ctx context.Context, f *Factory, args []string) int {
+	rootCmd := NewRootCmd(f)
+	rootCmd.SetArgs(args)
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		clierrors.Print(f.IO.ErrOut, err, f.OutputFormat == output.FormatJSON)
+		return clierrors.ExitCode(err)
+	}
+	return clierrors.ExitOK
+}
 
-	// Load config from file or create a default one
-	cfg, err = config.LoadConfig()
+// Execute runs the CLI with the process arguments and exits with the code of the error
+// class on failure, see the clierrors package.
+func Execute() {
+	if code := Run(context.Background(), NewFactory(), os.Args[1:]); code != clierrors.ExitOK {
+		os.Exit(code)
+	}
+}
+
+// exactArgs is cobra.ExactArgs reporting a ValidationError
+func exactArgs(n int) cobra.PositionalArgs {
+	return validateArgs(cobra.ExactArgs(n))
+}
+
+// minimumNArgs is cobra.MinimumNArgs reporting a ValidationError
+func minimumNArgs(n int) cobra.PositionalArgs {
+	return validateArgs(cobra.MinimumNArgs(n))
+}
+
+// validateArgs wraps a positional argument validator so that its errors map to the usage exit code
+func validateArgs(fn cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := fn(cmd, args); err != nil {
+			return usageError(cmd, err)
+		}
+		return nil
+	}
+}
+
+// usageError prints the command usage to the error stream and returns err as a ValidationError
+func usageError(cmd *cobra.Command, err error) error {
+	fmt.Fprintln(cmd.ErrOrStderr(), cmd.UsageString())
+	return &clierrors.ValidationError{Message: err.Error()}
+}
+
+// confirmAction asks for the confirmation of a destructive action, unless yes is set.
+// If the action is declined, or the input ends without an answer as when it is not a
+// terminal, it returns a *clierrors.CancelledError, which exits with ExitCancelled.
+func confirmAction(f *Factory, yes bool, question string) error {
+	if yes {
+		return nil
+	}
+	ok, err := f.Confirm(question)
+	if errors.Is(err, io.EOF) {
+		return &clierrors.CancelledError{Message: "cancelled, no answer to the confirmation, run again with --yes to skip it"}
+	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if !ok {
+		return &clierrors.CancelledError{Message: "cancelled, run again with --yes to skip the confirmation"}
 	}
+	return nil
+}
 
-	// Override config from command-line flags
-	if baseURL != "" {
-		cfg.BaseURL = baseURL
+// spaceCompletion completes space IDs and names, once the configuration is loaded
+func spaceCompletion(f *Factory) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		// Skip if we're not authenticated
+		if f.Setup() != nil || !f.Authenticated() {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return spaces.CompleteSpaces(cmd.Context(), f.Client())
 	}
 }
@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+	"github.com/spf13/cobra"
+)
+
+// newSchemaCmd creates the schema command
+func newSchemaCmd(f *Factory) *cobra.Command {
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Export and apply space schemas",
+		Long: `Export the object types, property definitions, tags and templates of a space
+to a schema file, apply a schema file to another space, and compare schemas.`,
+	}
+
+	schemaCmd.AddCommand(
+		newSchemaExportCmd(f),
+		newSchemaApplyCmd(f),
+		newSchemaDiffCmd(f),
+	)
+
+	return schemaCmd
+}
+
+// schemaExportOptions holds the flags of the schema export command
+type schemaExportOptions struct {
+	all bool
+}
+
+// newSchemaExportCmd creates the schema export command
+func newSchemaExportCmd(f *Factory) *cobra.Command {
+	opts := &schemaExportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export [spaceID|spaceName]",
+		Short: "Export the schema of a space",
+		Long: `Write the custom object types, property definitions, select tags and templates,
+with their icon and markdown body, of a space as YAML (or JSON with -o json), for
+use with 'schema apply':
+  anytype-cli schema export Engineering > schema.yaml
+
+The types and properties Anytype creates in every space are left out unless
+--all is given.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+			defer cancel()
+
+			schema, err := anytypecli.ExportSchema(ctx, f.Client(), f.API(), spaceID, anytypecli.ExportSchemaOptions{All: opts.all})
+			if err != nil {
+				return clierrors.Wrap(err, "failed to export schema")
+			}
+
+			if f.OutputFormat == output.FormatJSON {
+				return f.PrintStructured(schema)
+			}
+			// The schema is YAML in the table format too, so that it can be redirected to a file
+			formatted, err := output.FormatAsYAML(schema)
+			if err != nil {
+				return err
+			}
+			f.Print(formatted)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Include the built-in types and properties")
+
+	return cmd
+}
+
+// schemaApplyOptions holds the flags of the schema apply command
+type schemaApplyOptions struct {
+	file string
+	yes  bool
+}
+
+// schemaApplyResult is the structured output of the schema apply command
+type schemaApplyResult struct {
+	Changes []anytypecli.SchemaChange `json:"changes" yaml:"changes"`
+	Applied int                       `json:"applied" yaml:"applied"`
+}
+
+// newSchemaApplyCmd creates the schema apply command
+func newSchemaApplyCmd(f *Factory) *cobra.Command {
+	opts := &schemaApplyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply [spaceID|spaceName]",
+		Short: "Apply a schema file to a space",
+		Long: `Compare a schema file with a space and print the plan: the properties, tags,
+types and templates to create or update. The plan is applied only with --yes.
+
+Nothing is ever deleted: types, properties, tags and templates of the space that
+the schema does not mention are left as they are. Templates are matched by name
+within their type, and their body and icon are only updated when the schema gives
+them. Property format changes cannot be made through the API and are listed as
+manual steps.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.file == "" {
+				return clierrors.Validationf("a schema file is required (--file)")
+			}
+			schema, err := readSchemaFile(f, opts.file)
+			if err != nil {
+				return err
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+			defer cancel()
+
+			plan, err := anytypecli.PlanSchema(ctx, f.Client(), f.API(), spaceID, schema)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to plan schema changes")
+			}
+
+			applied := 0
+			var applyErr error
+			if opts.yes {
+				applied, applyErr = plan.Apply(ctx)
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				if err := f.PrintStructured(schemaApplyResult{Changes: plan.Changes, Applied: applied}); err != nil {
+					return err
+				}
+			default:
+				printSchemaPlan(f, plan)
+				switch {
+				case !plan.HasChanges():
+				case opts.yes && applyErr == nil:
+					f.Printf("\nApply complete: %d changes applied.\n", applied)
+				case !opts.yes:
+					f.Println("\nRun again with --yes to apply these changes.")
+				}
+			}
+			if applyErr != nil {
+				return clierrors.Wrap(applyErr, fmt.Sprintf("failed to apply schema after %d changes", applied))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Schema file to apply, '-' for standard input (required)")
+	cmd.Flags().BoolVarP(&opts.yes, "yes", "y", false, "Apply the plan instead of only printing it")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// schemaDiffOptions holds the flags of the schema diff command
+type schemaDiffOptions struct {
+	file string
+	all  bool
+}
+
+// newSchemaDiffCmd creates the schema diff command
+func newSchemaDiffCmd(f *Factory) *cobra.Command {
+	opts := &schemaDiffOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "diff [spaceA] [spaceB] | diff [space] -f schema.yaml",
+		Short: "Compare the schemas of two spaces, or of a space and a file",
+		Long: `Compare the schemas of two spaces, or a schema file with the schema of a space:
+types by key, property definitions by key and format, the tags of select
+properties and the names of templates.
+
+The differences are printed as a unified diff, or as a JSON Patch with -o json
+that turns the first schema into the second. The command exits with code 8 when
+the schemas differ, so that it can gate scripts:
+  anytype-cli schema diff Engineering "Engineering Archive"
+  anytype-cli schema diff Engineering -f schema.yaml -o json
+
+As with 'schema export', the built-in types and properties of the spaces are
+left out unless --all is given.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("file") {
+				return exactArgs(1)(cmd, args)
+			}
+			return exactArgs(2)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var from *anytypecli.Schema
+			fromName := opts.file
+			if opts.file != "" {
+				schema, err := readSchemaFile(f, opts.file)
+				if err != nil {
+					return err
+				}
+				from = schema
+			}
+
+			var schemas []*anytypecli.Schema
+			for _, spaceIdOrName := range args {
+				spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+				if err != nil {
+					return err
+				}
+
+				ctx, cancel := context.WithTimeout(cmd.Context(), 2*time.Minute)
+				schema, err := anytypecli.ExportSchema(ctx, f.Client(), f.API(), spaceID, anytypecli.ExportSchemaOptions{All: opts.all})
+				cancel()
+				if err != nil {
+					return clierrors.Wrap(err, "failed to export schema")
+				}
+				schemas = append(schemas, schema)
+			}
+			toName := args[len(args)-1]
+			if from == nil {
+				from, fromName = schemas[0], args[0]
+			}
+
+			diff := anytypecli.DiffSchemas(from, schemas[len(schemas)-1])
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				patch := diff.Patch
+				if patch == nil {
+					patch = []anytypecli.PatchOp{}
+				}
+				if err := f.PrintStructured(patch); err != nil {
+					return err
+				}
+			default:
+				if diff.Empty() {
+					f.Println("No differences.")
+				} else {
+					f.Print(diff.Unified(fromName, toName))
+				}
+			}
+
+			if !diff.Empty() {
+				return &clierrors.DifferencesError{Message: fmt.Sprintf("schemas differ (%d differences)", len(diff.Patch))}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Schema file to compare with the space, '-' for standard input")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Include the built-in types and properties of the spaces")
+
+	return cmd
+}
+
+// readSchemaFile reads a schema file, or the standard input if path is '-'
+func readSchemaFile(f *Factory, path string) (*anytypecli.Schema, error) {
+	var data io.Reader = f.IO.In
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, clierrors.Validationf("failed to read %s: %v", path, err)
+		}
+		defer file.Close()
+		data = file
+	}
+	schema, err := anytypecli.ReadSchema(data)
+	if err != nil {
+		return nil, clierrors.Validationf("%s: %v", path, err)
+	}
+	return schema, nil
+}
+
+// printSchemaPlan writes the create, update and manual changes of a plan, followed by a summary
+func printSchemaPlan(f *Factory, plan *anytypecli.SchemaPlan) {
+	symbols := map[string]string{
+		anytypecli.ActionCreate: "+",
+		anytypecli.ActionUpdate: "~",
+		anytypecli.ActionManual: "!",
+	}
+
+	for _, change := range plan.Changes {
+		if change.Action == anytypecli.ActionNoop {
+			continue
+		}
+		subject := fmt.Sprintf("%s %s", change.Resource, change.Key)
+		switch change.Resource {
+		case "tag":
+			subject = fmt.Sprintf("tag %q of property %s", change.Key, change.Parent)
+		case "template":
+			subject = fmt.Sprintf("template %q of type %s", change.Key, change.Parent)
+		}
+		f.Printf("  %s %s %s\n", symbols[change.Action], change.Action, subject)
+		for _, detail := range change.Details {
+			f.Printf("      %s\n", detail)
+		}
+	}
+
+	if !plan.HasChanges() && plan.Count(anytypecli.ActionManual) == 0 {
+		f.Println("No changes. The space matches the schema.")
+		return
+	}
+	f.Printf("\nPlan: %d to create, %d to update, %d unchanged, %d manual.\n",
+		plan.Count(anytypecli.ActionCreate),
+		plan.Count(anytypecli.ActionUpdate),
+		plan.Count(anytypecli.ActionNoop),
+		plan.Count(anytypecli.ActionManual))
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/internal/testserver"
+)
+
+func TestSchemaCommands(t *testing.T) {
+	runCommandTests(t, []commandTest{
+		{name: "schema_export", args: []string{"schema", "export", "Engineering"}, formats: []string{output.FormatTable}},
+		{name: "schema_export_all", args: []string{"schema", "export", "Engineering", "--all"},
+			formats: []string{output.FormatTable, output.FormatJSON}},
+		{name: "schema_apply", args: []string{"schema", "apply", "Engineering", "-f", "testdata/schema/team.yaml"}},
+		{name: "schema_apply_yes", args: []string{"schema", "apply", "Engineering", "-f", "testdata/schema/team.yaml", "--yes"},
+			formats: []string{output.FormatTable}},
+		{name: "schema_diff_spaces", args: []string{"schema", "diff", "Engineering", "Personal", "--all"},
+			wantCode: clierrors.ExitDifferences},
+		{name: "schema_diff_file", args: []string{"schema", "diff", "Engineering", "-f", "testdata/schema/team.yaml", "--all"},
+			wantCode: clierrors.ExitDifferences, formats: []string{output.FormatTable, output.FormatJSON}},
+		{name: "schema_diff_same", args: []string{"schema", "diff", "Engineering", testserver.SpaceEngineering},
+			formats: []string{output.FormatTable, output.FormatJSON}},
+		{name: "schema_diff_missing_space", args: []string{"schema", "diff", "Engineering"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatJSON}},
+		{name: "schema_apply_invalid", args: []string{"schema", "apply", "Engineering", "-f", "testdata/schema/invalid.yaml"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+	})
+}
+
+func TestSchemaApplyIsIdempotent(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	for i := 0; i < 2; i++ {
+		stdout, stderr, code := runCLI(t, srv, "schema", "apply", testserver.SpaceEngineering, "-f", "testdata/schema/team.yaml", "--yes")
+		if code != clierrors.ExitOK {
+			t.Fatalf("apply %d: exit code = %d, stderr: %s", i+1, code, stderr)
+		}
+		if i == 1 && !strings.Contains(stdout, "Plan: 0 to create, 0 to update") {
+			t.Errorf("second apply changed the space:\n%s", stdout)
+		}
+	}
+}
+
+func TestSchemaApplyCreatesTemplates(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	if _, stderr, code := runCLI(t, srv, "schema", "apply", testserver.SpaceEngineering, "-f", "testdata/schema/team.yaml", "--yes"); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	stdout, stderr, code := runCLI(t, srv, "templates", "show", testserver.SpaceEngineering, "incident", "Postmortem")
+	if code != clierrors.ExitOK {
+		t.Fatalf("templates show: exit code = %d, stderr: %s", code, stderr)
+	}
+	if want := "## Root cause"; !strings.Contains(stdout, want) {
+		t.Errorf("templates show:\n%s\nwant the body of the schema", stdout)
+	}
+}
+
+func TestSchemaExportApplyRoundTrip(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+	srv.Lock()
+	setTemplateBody(srv)
+	srv.Unlock()
+
+	exported, stderr, code := runCLI(t, srv, "schema", "export", testserver.SpaceEngineering, "--all")
+	if code != clierrors.ExitOK {
+		t.Fatalf("export: exit code = %d, stderr: %s", code, stderr)
+	}
+	file := filepath.Join(t.TempDir(), "schema.yaml")
+	if err := os.WriteFile(file, []byte(exported), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, stderr, code := runCLI(t, srv, "schema", "apply", testserver.SpacePersonal, "-f", file, "--yes"); code != clierrors.ExitOK {
+		t.Fatalf("apply: exit code = %d, stderr: %s", code, stderr)
+	}
+
+	stdout, _, _ := runCLI(t, srv, "schema", "apply", testserver.SpacePersonal, "-f", file)
+	if !strings.Contains(stdout, "No changes. The space matches the schema.") {
+		t.Errorf("plan after apply:\n%s", stdout)
+	}
+
+	stdout, _, _ = runCLI(t, srv, "templates", "show", testserver.SpacePersonal, "ot-task", "Default Task")
+	if want := "## Definition of done"; !strings.Contains(stdout, want) {
+		t.Errorf("copied template:\n%s\nwant the body of the exported template", stdout)
+	}
+}
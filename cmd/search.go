@@ -2,142 +2,174 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
+	"strings"
 	"time"
 
-	"github.com/epheo/anytype-cli/internal/auth"
-	"github.com/epheo/anytype-cli/internal/client"
+	"github.com/epheo/anytype-cli/internal/clierrors"
 	"github.com/epheo/anytype-cli/internal/output"
-	"github.com/epheo/anytype-cli/internal/spaces"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
 	"github.com/epheo/anytype-go"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
-// searchCmd represents the search command
-var searchCmd = &cobra.Command{
-	Use:   "search",
-	Short: "Search for objects",
-	Long:  `Search for objects in Anytype spaces.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
-
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-
-		anytypeClient := client.GetClient(cfg)
-
-		var searchReq anytype.SearchRequest
-		searchReq.Query = searchQuery
-
-		if len(searchTypes) > 0 {
-			searchReq.Types = searchTypes
-		}
-
-		if searchSortProperty != "" {
-			searchReq.Sort = &anytype.SortOptions{
-				Property:  anytype.SortProperty(searchSortProperty),
-				Direction: anytype.SortDirection(searchSortDirection),
-			}
-		}
-
-		var resp *anytype.SearchResponse
-		var err error
+// searchOptions holds the flags of the search command
+type searchOptions struct {
+	query         string
+	types         []string
+	where         []string
+	sorts         []string
+	sortDirection string
+	createdAfter  string
+	modifiedSince string
+	archived      bool
+	tags          []string
+	layouts       []string
+	spaceID       string
+}
 
-		if searchSpaceID != "" {
-			// Search within a specific space
-			// Resolve space ID if it's a name
-			spaceID, spaceErr := spaces.ResolveSpace(cfg, searchSpaceID)
-			if spaceErr != nil {
-				fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", spaceErr)
-				os.Exit(1)
+// newSearchCmd creates the search command
+func newSearchCmd(f *Factory) *cobra.Command {
+	opts := &searchOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Search for objects",
+		Long: `Search for objects in Anytype spaces.
+
+Besides --query and --types, results can be filtered by property with --where, using
+the expressions of list views (e.g. 'status = "In Progress"' or 'due_date < 2026-11-01'),
+by creation or modification date, layout and tag, and sorted by several keys in turn.
+The API only filters by query and type and sorts by one of created_date,
+last_modified_date, last_opened_date and name; the other filters and sorts are applied
+to the properties of the results. A --where filter on type takes a type key or name.
+Ages in hours, as in --modified-since 12h, compare to the second; other dates compare
+by day. --archived searches the archived objects by name, as the API does not search
+them.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			q, err := opts.searchQuery(f.Now())
+			if err != nil {
+				return err
 			}
-			resp, err = anytypeClient.Space(spaceID).Search(ctx, searchReq)
-		} else {
-			// Global search across all spaces
-			resp, err = anytypeClient.Search().Search(ctx, searchReq)
-		}
-
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to search: %v\n", err)
-			os.Exit(1)
-		}
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Data, "", "  ")
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+			spaceID := ""
+			if opts.spaceID != "" {
+				// Resolve space ID if it's a name
+				if spaceID, err = f.ResolveSpace(cmd.Context(), opts.spaceID); err != nil {
+					return err
+				}
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Data)
+			objects, err := anytypecli.SearchObjects(ctx, f.API(), spaceID, q, f.Now())
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return clierrors.Wrap(err, "failed to search")
 			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Table format with dynamic column widths
-			table := output.NewTable([]string{"OBJECT ID", "NAME", "TYPE", "SPACE ID"})
-			// Don't truncate ID columns (0 and 3) as they're used for command line arguments
-			table.SetColumnWidth(1, 30)
-			table.SetColumnTruncate(1, true) // NAME column
-			table.SetColumnWidth(2, 20)
-			table.SetColumnTruncate(2, true) // TYPE column
-
-			for _, obj := range resp.Data {
-				table.AddRow([]string{obj.ID, obj.Name, obj.TypeKey, obj.SpaceID})
+			if objects == nil {
+				objects = []anytype.Object{}
 			}
-			fmt.Print(table.String())
-			fmt.Printf("\nTotal results: %d\n", len(resp.Data))
 
-			// Print search details
-			fmt.Printf("\nSearch details:\n")
-			fmt.Printf("  Query: '%s'\n", searchQuery)
-			if len(searchTypes) > 0 {
-				fmt.Printf("  Types: %v\n", searchTypes)
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(objects)
+			default:
+				// Table format with dynamic column widths
+				table := output.NewTable([]string{"OBJECT ID", "NAME", "TYPE", "SPACE ID"})
+				// Don't truncate ID columns (0 and 3) as they're used for command line arguments
+				table.SetColumnWidth(1, 30)
+				table.SetColumnTruncate(1, true) // NAME column
+				table.SetColumnWidth(2, 20)
+				table.SetColumnTruncate(2, true) // TYPE column
+
+				for _, obj := range objects {
+					table.AddRow([]string{obj.ID, obj.Name, obj.TypeKey, obj.SpaceID})
+				}
+				f.Print(table.String())
+				f.Printf("\nTotal results: %d\n", len(objects))
+
+				// Print search details
+				f.Printf("\nSearch details:\n")
+				f.Printf("  Query: '%s'\n", opts.query)
+				if len(opts.types) > 0 {
+					f.Printf("  Types: %v\n", opts.types)
+				}
+				for _, filter := range q.Filters {
+					f.Printf("  Where: %s\n", anytypecli.FormatFilter(filter))
+				}
+				if len(q.Layouts) > 0 {
+					f.Printf("  Layouts: %v\n", q.Layouts)
+				}
+				if len(q.Tags) > 0 {
+					f.Printf("  Tags: %v\n", q.Tags)
+				}
+				if len(q.Sorts) > 0 {
+					sorts := make([]string, 0, len(q.Sorts))
+					for _, sort := range q.Sorts {
+						sorts = append(sorts, fmt.Sprintf("%s (%s)", sort.PropertyKey, sort.SortType))
+					}
+					f.Printf("  Sorted by: %s\n", strings.Join(sorts, ", "))
+				}
+				if q.Archived {
+					f.Printf("  Archived objects only\n")
+				}
+				if opts.spaceID != "" {
+					f.Printf("  Limited to space: %s\n", opts.spaceID)
+				} else {
+					f.Printf("  Searched across all spaces\n")
+				}
 			}
-			if searchSortProperty != "" {
-				fmt.Printf("  Sorted by: %s (%s)\n", searchSortProperty, searchSortDirection)
-			}
-			if searchSpaceID != "" {
-				fmt.Printf("  Limited to space: %s\n", searchSpaceID)
-			} else {
-				fmt.Printf("  Searched across all spaces\n")
-			}
-		}
-	},
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.query, "query", "", "Search query string")
+	cmd.Flags().StringSliceVar(&opts.types, "types", []string{}, "Filter by object types (comma-separated, e.g. 'ot-page,ot-note')")
+	cmd.Flags().StringArrayVar(&opts.where, "where", nil, "Property filter expression, e.g. 'status = Done' or 'due_date < today' (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.sorts, "sort", nil, "Key to sort results by, as key, key:asc or key:desc (repeatable, applied in turn)")
+	cmd.Flags().StringVar(&opts.sortDirection, "direction", "desc", "Sort direction of the sort keys without one (asc or desc)")
+	cmd.Flags().StringVar(&opts.createdAfter, "created-after", "", "Only objects created after this date, e.g. 2026-10-01 or today-7d")
+	cmd.Flags().StringVar(&opts.modifiedSince, "modified-since", "", "Only objects modified within this age, e.g. 7d, 2w or 12h")
+	cmd.Flags().BoolVar(&opts.archived, "archived", false, "Search the archived objects instead")
+	cmd.Flags().StringArrayVar(&opts.tags, "has-tag", nil, "Only objects with this tag on a select or multi-select property (repeatable)")
+	cmd.Flags().StringSliceVar(&opts.layouts, "layout", nil, "Filter by layouts (comma-separated, e.g. 'basic,action')")
+	cmd.Flags().StringVar(&opts.spaceID, "space", "", "Limit search to this space (can be either ID or name, default: search all spaces)")
+	cmd.RegisterFlagCompletionFunc("space", spaceCompletion(f))
+
+	return cmd
 }
 
-var (
-	searchQuery         string
-	searchTypes         []string
-	searchSortProperty  string
-	searchSortDirection string
-	searchSpaceID       string
-)
-
-func init() {
-	rootCmd.AddCommand(searchCmd)
-
-	searchCmd.Flags().StringVar(&searchQuery, "query", "", "Search query string")
-	searchCmd.Flags().StringSliceVar(&searchTypes, "types", []string{}, "Filter by object types (comma-separated, e.g. 'ot-page,ot-note')")
-	searchCmd.Flags().StringVar(&searchSortProperty, "sort", "", "Property to sort results by (created_date, last_modified_date, last_opened_date, name)")
-	searchCmd.Flags().StringVar(&searchSortDirection, "direction", "desc", "Sort direction (asc or desc)")
-	searchCmd.Flags().StringVar(&searchSpaceID, "space", "", "Limit search to this space (can be either ID or name, default: search all spaces)")
-
-	// Set up completion functions after config is loaded
-	cobra.OnInitialize(func() {
-		if cfg != nil {
-			spaceCompletion := spaces.GetSpaceCompletionFunc(cfg)
-			searchCmd.RegisterFlagCompletionFunc("space", spaceCompletion)
+// searchQuery returns the search described by the flags
+func (opts *searchOptions) searchQuery(now time.Time) (anytypecli.SearchQuery, error) {
+	q := anytypecli.SearchQuery{Query: opts.query, Types: opts.types, Layouts: opts.layouts, Tags: opts.tags, Archived: opts.archived}
+	if opts.sortDirection != anytypecli.SortAscending && opts.sortDirection != anytypecli.SortDescending {
+		return q, clierrors.Validationf("invalid --direction '%s' (expected asc or desc)", opts.sortDirection)
+	}
+	sorts := make([]string, 0, len(opts.sorts))
+	for _, sort := range opts.sorts {
+		if !strings.Contains(sort, ":") {
+			sort += ":" + opts.sortDirection
+		}
+		sorts = append(sorts, sort)
+	}
+	var err error
+	if q.Filters, q.Sorts, err = parseFiltersAndSorts(opts.where, sorts); err != nil {
+		return q, err
+	}
+
+	if opts.createdAfter != "" {
+		if _, ok := anytypecli.ParseDate(opts.createdAfter, now); !ok {
+			return q, clierrors.Validationf("invalid --created-after '%s' (expected e.g. 2026-10-01 or today-7d)", opts.createdAfter)
+		}
+		q.Filters = append(q.Filters, anytype.ListFilter{PropertyKey: anytypecli.CreatedDateProperty, Condition: anytypecli.ConditionGreater, Value: opts.createdAfter})
+	}
+	if opts.modifiedSince != "" {
+		age, err := anytypecli.ParseAge(opts.modifiedSince)
+		if err != nil {
+			return q, clierrors.Validationf("invalid --modified-since: %v", err)
 		}
-	})
+		q.Filters = append(q.Filters, anytype.ListFilter{PropertyKey: anytypecli.LastModifiedDateProperty,
+			Condition: anytypecli.ConditionGreaterOrEqual, Value: now.Add(-age).Format(time.RFC3339)})
+	}
+	return q, nil
 }
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/internal/testserver"
+)
+
+func TestSearchCommand(t *testing.T) {
+	runCommandTests(t, []commandTest{
+		{name: "search_global", args: []string{"search", "--query", "i"}},
+		{name: "search_space_types", args: []string{"search", "--space", "Engineering", "--types", "ot-task", "--sort", "name", "--direction", "asc"}},
+		{name: "search_where", args: []string{"search", "--space", "Engineering", "--where", `status = "In Progress"`}, formats: []string{output.FormatTable}},
+		{name: "search_sorts", args: []string{"search", "--space", "Engineering", "--layout", "action", "--sort", "status:desc", "--sort", "name"},
+			formats: []string{output.FormatTable}},
+		{name: "search_has_tag", args: []string{"search", "--has-tag", "done"}, formats: []string{output.FormatTable}},
+		{name: "search_archived_modified_since", args: []string{"search", "--space", "Engineering Archive", "--archived", "--modified-since", "7d"}},
+		{name: "search_invalid_where", args: []string{"search", "--where", "status"}, wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "search_invalid_created_after", args: []string{"search", "--created-after", "soon"}, wantCode: clierrors.ExitValidation,
+			formats: []string{output.FormatTable}},
+	})
+}
+
+func TestSearchPushesTypeFilter(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	if _, stderr, code := runCLI(t, srv, "search", "--where", "type = ot-task", "--sort", "name:asc"); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	search := srv.Requests[len(srv.Requests)-1]
+	if search.Body != `{"query":"","types":["ot-task"],"sort":{"property":"name","direction":"asc"}}` {
+		t.Errorf("search request %s", search.Body)
+	}
+}
+
+func TestSearchResolvesTypeName(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	stdout, stderr, code := runCLI(t, srv, "search", "--space", "Engineering", "--where", "type = task")
+	if code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, testserver.ObjectFixBug) {
+		t.Errorf("search output:\n%s\nwant the tasks", stdout)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	search := srv.Requests[len(srv.Requests)-1]
+	if search.Body != `{"query":"","types":["ot-task"]}` {
+		t.Errorf("search request %s, want the key of the type", search.Body)
+	}
+}
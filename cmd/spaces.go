@@ -2,229 +2,454 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
 	"time"
 
-	"github.com/epheo/anytype-cli/internal/auth"
-	"github.com/epheo/anytype-cli/internal/client"
+	"github.com/epheo/anytype-cli/internal/clierrors"
 	"github.com/epheo/anytype-cli/internal/output"
-	"github.com/epheo/anytype-cli/internal/spaces"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
 	"github.com/epheo/anytype-go"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
-// spacesCmd represents the spaces command
-var spacesCmd = &cobra.Command{
-	Use:   "spaces",
-	Short: "Manage Anytype spaces",
-	Long:  `List, create, and manage Anytype spaces.`,
+// newSpacesCmd creates the spaces command
+func newSpacesCmd(f *Factory) *cobra.Command {
+	spacesCmd := &cobra.Command{
+		Use:   "spaces",
+		Short: "Manage Anytype spaces",
+		Long: `List, create, and manage Anytype spaces.
+
+Spaces cannot be deleted or left through the Anytype API; use the Anytype app.`,
+	}
+
+	spacesCmd.AddCommand(
+		newSpacesListCmd(f),
+		newSpacesCreateCmd(f),
+		newSpacesGetCmd(f),
+		newSpacesUpdateCmd(f),
+		newSpacesStatsCmd(f),
+		newSpacesCloneCmd(f),
+	)
+
+	return spacesCmd
 }
 
-// spacesListCmd represents the spaces list command
-var spacesListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all spaces",
-	Long:  `List all spaces accessible to the authenticated user.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// newSpacesListCmd creates the spaces list command
+func newSpacesListCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List all spaces",
+		Long:  `List all spaces accessible to the authenticated user.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			resp, err := f.Client().Spaces().List(ctx)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to list spaces")
+			}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(resp.Data)
+			default:
+				// Table format with dynamic column widths
+				table := output.NewTable([]string{"SPACE ID", "NAME", "DESCRIPTION"})
+				// Don't truncate ID column (index 0) as it's used for command line arguments
+				// Set reasonable max width for NAME and DESCRIPTION columns
+				table.SetColumnWidth(1, 30)
+				table.SetColumnTruncate(1, true) // NAME column
+				table.SetColumnWidth(2, 40)
+				table.SetColumnTruncate(2, true) // DESCRIPTION column
 
-		anytypeClient := client.GetClient(cfg)
-		resp, err := anytypeClient.Spaces().List(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to list spaces: %v\n", err)
-			os.Exit(1)
-		}
+				for _, space := range resp.Data {
+					table.AddRow([]string{space.ID, space.Name, space.Description})
+				}
+				f.Print(table.String())
+				f.Printf("\nTotal spaces: %d\n", len(resp.Data))
+			}
+			return nil
+		},
+	}
+}
+
+// spacesCreateOptions holds the flags of the spaces create command
+type spacesCreateOptions struct {
+	name string
+	desc string
+	icon string
+}
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Data, "", "  ")
+// newSpacesCreateCmd creates the spaces create command
+func newSpacesCreateCmd(f *Factory) *cobra.Command {
+	opts := &spacesCreateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a new space",
+		Long:  `Create a new Anytype space with the specified name and description.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Validate inputs
+			if opts.name == "" {
+				return clierrors.Validationf("space name is required")
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			var icon *anytype.Icon
+			if opts.icon != "" {
+				icon = &anytype.Icon{
+					Format: anytype.IconFormatEmoji,
+					Emoji:  opts.icon,
+				}
+			}
+
+			createReq := anytype.CreateSpaceRequest{
+				Name:        opts.name,
+				Description: opts.desc,
+				Icon:        icon,
+			}
+
+			space, err := f.API().CreateSpace(ctx, createReq)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return clierrors.Wrap(err, "failed to create space")
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Data)
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(space)
+			default:
+				f.Println("Space created successfully:")
+				f.Printf("ID: %s\n", space.ID)
+				f.Printf("Name: %s\n", space.Name)
+				f.Printf("Description: %s\n", space.Description)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name for the new space (required)")
+	cmd.Flags().StringVar(&opts.desc, "description", "", "Description for the new space")
+	cmd.Flags().StringVar(&opts.icon, "icon", "", "Emoji icon for the space (e.g. '🚀')")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// newSpacesGetCmd creates the spaces get command
+func newSpacesGetCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [spaceID|spaceName]",
+		Short: "Get details of a specific space",
+		Long:  `Retrieve detailed information about a specific Anytype space.`,
+		Args:  exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
-			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Table format with dynamic column widths
-			table := output.NewTable([]string{"SPACE ID", "NAME", "DESCRIPTION"})
-			// Don't truncate ID column (index 0) as it's used for command line arguments
-			// Set reasonable max width for NAME and DESCRIPTION columns
-			table.SetColumnWidth(1, 30)
-			table.SetColumnTruncate(1, true) // NAME column
-			table.SetColumnWidth(2, 40)
-			table.SetColumnTruncate(2, true) // DESCRIPTION column
-
-			for _, space := range resp.Data {
-				table.AddRow([]string{space.ID, space.Name, space.Description})
-			}
-			fmt.Print(table.String())
-			fmt.Printf("\nTotal spaces: %d\n", len(resp.Data))
-		}
-	},
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			resp, err := f.Client().Space(spaceID).Get(ctx)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to get space")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(resp.Space)
+			default:
+				// Detailed output
+				space := resp.Space
+				f.Println("SPACE DETAILS")
+				f.Println("------------")
+				f.Printf("ID: %s\n", space.ID)
+				f.Printf("Name: %s\n", space.Name)
+				f.Printf("Description: %s\n", space.Description)
+				f.Printf("Home Object ID: %s\n", space.HomeID)
+				f.Printf("Archive ID: %s\n", space.ArchiveID)
+				f.Printf("Profile ID: %s\n", space.ProfileID)
+				f.Printf("Created At: %s\n", formatTime(space.CreatedAt))
+				f.Printf("Last Opened At: %s\n", formatTime(space.LastOpenedAt))
+				if space.Icon != nil {
+					f.Printf("Icon: %s (%s)\n", space.Icon.Emoji, space.Icon.Format)
+				}
+			}
+			return nil
+		},
+	}
 }
 
-// spacesCreateCmd represents the spaces create command
-var spacesCreateCmd = &cobra.Command{
-	Use:   "create",
-	Short: "Create a new space",
-	Long:  `Create a new Anytype space with the specified name and description.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// newSpacesUpdateCmd creates the spaces update command
+func newSpacesUpdateCmd(f *Factory) *cobra.Command {
+	opts := &spacesCreateOptions{}
 
-		// Validate inputs
-		if spaceName == "" {
-			fmt.Println("Space name is required")
-			os.Exit(1)
-		}
+	cmd := &cobra.Command{
+		Use:   "update [spaceID|spaceName]",
+		Short: "Update a space",
+		Long: `Change the name, description or icon of an Anytype space. Only the given
+flags are changed; --description "" clears the description.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var req anytypecli.UpdateSpaceRequest
+			if cmd.Flags().Changed("name") {
+				if opts.name == "" {
+					return clierrors.Validationf("space name must not be empty")
+				}
+				req.Name = &opts.name
+			}
+			if cmd.Flags().Changed("description") {
+				req.Description = &opts.desc
+			}
+			if opts.icon != "" {
+				req.Icon = emojiIcon(opts.icon)
+			}
+			if req == (anytypecli.UpdateSpaceRequest{}) {
+				return clierrors.Validationf("nothing to update, give --name, --description or --icon")
+			}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
 
-		anytypeClient := client.GetClient(cfg)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
 
-		var icon *anytype.Icon
-		if spaceIcon != "" {
-			icon = &anytype.Icon{
-				Format: anytype.IconFormatEmoji,
-				Emoji:  spaceIcon,
+			space, err := f.API().UpdateSpace(ctx, spaceID, req)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to update space")
 			}
-		}
 
-		createReq := anytype.CreateSpaceRequest{
-			Name:        spaceName,
-			Description: spaceDesc,
-			Icon:        icon,
-		}
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(space)
+			default:
+				f.Println("Space updated successfully:")
+				f.Printf("ID: %s\n", space.ID)
+				f.Printf("Name: %s\n", space.Name)
+				f.Printf("Description: %s\n", space.Description)
+			}
+			return nil
+		},
+	}
 
-		resp, err := anytypeClient.Spaces().Create(ctx, createReq)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to create space: %v\n", err)
-			os.Exit(1)
-		}
+	cmd.Flags().StringVar(&opts.name, "name", "", "New name for the space")
+	cmd.Flags().StringVar(&opts.desc, "description", "", "New description for the space")
+	cmd.Flags().StringVar(&opts.icon, "icon", "", "New emoji icon for the space (e.g. '🚀')")
+
+	return cmd
+}
+
+// spacesStatsOptions holds the flags of the spaces stats command
+type spacesStatsOptions struct {
+	top        int
+	weeks      int
+	skipBodies bool
+}
+
+// newSpacesStatsCmd creates the spaces stats command
+func newSpacesStatsCmd(f *Factory) *cobra.Command {
+	opts := &spacesStatsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "stats [spaceID|spaceName]",
+		Short: "Show statistics about the contents of a space",
+		Long: `Summarize the contents of an Anytype space: objects by type and layout, archived
+objects, lists, members by role, objects created and modified per week, the largest
+objects by body size and the untitled objects.
+
+Measuring bodies exports every object as markdown; --skip-bodies leaves it out on
+large spaces. JSON and YAML output suit charting the growth of a space over time.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.top < 0 || opts.weeks < 1 {
+				return clierrors.Validationf("--top must not be negative and --weeks must be at least 1")
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+			defer cancel()
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Space, "", "  ")
+			objects, err := anytypecli.AllObjects(ctx, f.API(), spaceID)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return clierrors.Wrap(err, "failed to list objects")
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Space)
+			members, err := anytypecli.Collect(f.API().Members(ctx, spaceID))
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
-			}
-			fmt.Println(string(yamlOutput))
-		default:
-			fmt.Println("Space created successfully:")
-			fmt.Printf("ID: %s\n", resp.Space.ID)
-			fmt.Printf("Name: %s\n", resp.Space.Name)
-			fmt.Printf("Description: %s\n", resp.Space.Description)
-		}
-	},
+				return clierrors.Wrap(err, "failed to list members")
+			}
+
+			var bodySizes map[string]int
+			if !opts.skipBodies {
+				bodySizes = map[string]int{}
+				for _, obj := range objects {
+					if obj.Archived {
+						continue
+					}
+					markdown, err := anytypecli.ExportMarkdown(ctx, f.Client(), spaceID, obj.ID)
+					if err != nil {
+						return clierrors.Wrap(err, fmt.Sprintf("failed to export object %s", obj.ID))
+					}
+					bodySizes[obj.ID] = len(markdown)
+				}
+			}
+
+			stats := anytypecli.ComputeStats(objects, members, bodySizes, opts.top, f.Now(), opts.weeks)
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(stats)
+			default:
+				printSpaceStats(f, stats, opts.skipBodies)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.top, "top", 5, "Number of largest objects to show")
+	cmd.Flags().IntVar(&opts.weeks, "weeks", 8, "Number of weeks of activity to show, up to this week")
+	cmd.Flags().BoolVar(&opts.skipBodies, "skip-bodies", false, "Do not export the objects to measure their bodies")
+
+	return cmd
 }
 
-// spacesGetCmd represents the spaces get command
-var spacesGetCmd = &cobra.Command{
-	Use:   "get [spaceID|spaceName]",
-	Short: "Get details of a specific space",
-	Long:  `Retrieve detailed information about a specific Anytype space.`,
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// spacesCloneOptions holds the flags of the spaces clone command
+type spacesCloneOptions struct {
+	spacesCreateOptions
+	structureOnly bool
+}
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
-		}
+// newSpacesCloneCmd creates the spaces clone command
+func newSpacesCloneCmd(f *Factory) *cobra.Command {
+	opts := &spacesCloneOptions{}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+	cmd := &cobra.Command{
+		Use:   "clone [spaceID|spaceName]",
+		Short: "Clone a space into a new space",
+		Long: `Create a new space with the types, properties, tags, templates and lists of an
+existing space and copies of its objects. Links between the copied objects, in their
+bodies and object properties, and the contents of collections point to the copies.
 
-		anytypeClient := client.GetClient(cfg)
-		resp, err := anytypeClient.Space(spaceID).Get(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to get space: %v\n", err)
-			os.Exit(1)
-		}
+--structure-only copies everything but the objects, to start a new project from the
+setup of an existing one. The description and icon default to those of the source.
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Space, "", "  ")
+Views cannot be created through the Anytype API: lists get their default view, and the
+views that could not be recreated are reported along with anything else left out.`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.name == "" {
+				return clierrors.Validationf("space name is required")
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Space)
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Minute)
+			defer cancel()
+
+			cloneOpts := anytypecli.CloneOptions{Name: opts.name, Description: opts.desc, StructureOnly: opts.structureOnly}
+			if opts.icon != "" {
+				cloneOpts.Icon = emojiIcon(opts.icon)
+			}
+			report, err := anytypecli.CloneSpace(ctx, f.Client(), f.API(), spaceID, cloneOpts)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
-			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Detailed output
-			space := resp.Space
-			fmt.Println("SPACE DETAILS")
-			fmt.Println("------------")
-			fmt.Printf("ID: %s\n", space.ID)
-			fmt.Printf("Name: %s\n", space.Name)
-			fmt.Printf("Description: %s\n", space.Description)
-			fmt.Printf("Home Object ID: %s\n", space.HomeID)
-			fmt.Printf("Archive ID: %s\n", space.ArchiveID)
-			fmt.Printf("Profile ID: %s\n", space.ProfileID)
-			fmt.Printf("Created At: %s\n", formatTime(space.CreatedAt))
-			fmt.Printf("Last Opened At: %s\n", formatTime(space.LastOpenedAt))
-			if space.Icon != nil {
-				fmt.Printf("Icon: %s (%s)\n", space.Icon.Emoji, space.Icon.Format)
+				if report.Space.ID != "" {
+					return clierrors.Wrap(err, fmt.Sprintf("failed to clone space, %s (%s) was created but is incomplete", report.Space.Name, report.Space.ID))
+				}
+				return clierrors.Wrap(err, "failed to clone space")
 			}
-		}
-	},
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(report)
+			default:
+				f.Println("Space cloned successfully:")
+				f.Printf("ID: %s\n", report.Space.ID)
+				f.Printf("Name: %s\n", report.Space.Name)
+				f.Printf("Schema changes: %d\n", report.SchemaChanges)
+				f.Printf("Templates: %d\n", report.Templates)
+				f.Printf("Lists: %d\n", report.Lists)
+				if !opts.structureOnly {
+					f.Printf("Objects: %d (%d with remapped links)\n", report.Objects, report.LinksRemapped)
+				}
+				if len(report.Issues) > 0 {
+					f.Printf("\nNot copied (%d):\n", len(report.Issues))
+					table := output.NewTable([]string{"RESOURCE", "NAME", "REASON"})
+					for _, issue := range report.Issues {
+						table.AddRow([]string{issue.Resource, issue.Name, issue.Reason})
+					}
+					f.Print(table.String())
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name for the new space (required)")
+	cmd.Flags().StringVar(&opts.desc, "description", "", "Description for the new space (default: that of the source)")
+	cmd.Flags().StringVar(&opts.icon, "icon", "", "Emoji icon for the new space (default: that of the source)")
+	cmd.Flags().BoolVar(&opts.structureOnly, "structure-only", false, "Copy types, properties, templates and lists but no objects")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
 }
 
-var (
-	spaceName string
-	spaceDesc string
-	spaceIcon string
-)
+// printSpaceStats writes the sections of a space statistics report
+func printSpaceStats(f *Factory, stats anytypecli.SpaceStats, skipBodies bool) {
+	f.Printf("Objects: %d\n", stats.Objects)
+	f.Printf("Archived: %d\n", stats.Archived)
+	f.Printf("Lists: %d\n", stats.Lists)
+
+	printCounts := func(title, header string, counts []anytypecli.Count) {
+		f.Printf("\n%s\n", title)
+		if len(counts) == 0 {
+			f.Println("  (none)")
+			return
+		}
+		table := output.NewTable([]string{header, "COUNT"})
+		for _, count := range counts {
+			table.AddRow([]string{count.Name, fmt.Sprint(count.Count)})
+		}
+		f.Print(table.String())
+	}
+	printCounts("Objects by type:", "TYPE", stats.ByType)
+	printCounts("Objects by layout:", "LAYOUT", stats.ByLayout)
+	printCounts("Active members by role:", "ROLE", stats.MembersByRole)
+
+	f.Println("\nActivity by week:")
+	table := output.NewTable([]string{"WEEK", "CREATED", "MODIFIED"})
+	for _, week := range stats.Weeks {
+		table.AddRow([]string{week.Week, fmt.Sprint(week.Created), fmt.Sprint(week.Modified)})
+	}
+	f.Print(table.String())
+
+	if !skipBodies {
+		f.Println("\nLargest objects:")
+		table := output.NewTable([]string{"OBJECT ID", "NAME", "BYTES"})
+		for _, obj := range stats.Largest {
+			table.AddRow([]string{obj.ID, obj.Name, fmt.Sprint(obj.Bytes)})
+		}
+		f.Print(table.String())
+	}
 
-func init() {
-	rootCmd.AddCommand(spacesCmd)
-	spacesCmd.AddCommand(spacesListCmd)
-	spacesCmd.AddCommand(spacesCreateCmd)
-	spacesCmd.AddCommand(spacesGetCmd)
-
-	// Flags for create command
-	spacesCreateCmd.Flags().StringVar(&spaceName, "name", "", "Name for the new space (required)")
-	spacesCreateCmd.Flags().StringVar(&spaceDesc, "description", "", "Description for the new space")
-	spacesCreateCmd.Flags().StringVar(&spaceIcon, "icon", "", "Emoji icon for the space (e.g. '🚀')")
-	spacesCreateCmd.MarkFlagRequired("name")
+	f.Printf("\nUntitled objects: %d\n", len(stats.Untitled))
+	for _, obj := range stats.Untitled {
+		f.Printf("  %s (%s)\n", obj.ID, obj.TypeKey)
+	}
 }
 
 // Helper functions
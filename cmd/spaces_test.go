@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/internal/testserver"
+	"github.com/epheo/anytype-go"
+)
+
+func TestSpacesCommands(t *testing.T) {
+	runCommandTests(t, []commandTest{
+		{name: "spaces_list", args: []string{"spaces", "list"}},
+		{name: "spaces_get", args: []string{"spaces", "get", "engineering"}},
+		{name: "spaces_get_partial", args: []string{"spaces", "get", "Pers"}},
+		{name: "spaces_create", args: []string{"spaces", "create", "--name", "Design", "--description", "Design work", "--icon", "🎨"}},
+		{name: "spaces_stats", args: []string{"spaces", "stats", "Engineering", "--weeks", "2"}, formats: []string{output.FormatTable, output.FormatJSON}},
+		{name: "spaces_stats_skip_bodies", args: []string{"spaces", "stats", "Personal", "--skip-bodies", "--weeks", "1"},
+			formats: []string{output.FormatTable}},
+		{name: "spaces_update", args: []string{"spaces", "update", "Personal", "--name", "Home", "--description", "", "--icon", "🏠"}},
+		{name: "spaces_clone", args: []string{"spaces", "clone", "Engineering", "--name", "New Project"}, setup: setSetSource},
+		{name: "spaces_clone_structure_only", args: []string{"spaces", "clone", "Engineering", "--name", "New Project", "--structure-only"},
+			formats: []string{output.FormatTable}, setup: setSetSource},
+		{name: "spaces_update_nothing", args: []string{"spaces", "update", "Personal"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "spaces_update_empty_name", args: []string{"spaces", "update", "Personal", "--name", ""},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+	})
+}
+
+// setSetSource adds the All Tasks set with the set_of property the API returns for sets,
+// which spaces clone reads the source types of the copied set from
+func setSetSource(srv *testserver.Server) {
+	addAllTasks(srv)
+	for _, obj := range srv.Objects[testserver.SpaceEngineering] {
+		if obj.ID == objectAllTasks {
+			obj.Properties = append(obj.Properties, anytype.Property{Key: "set_of", Name: "Set of", Format: "objects", Objects: []string{"ot-task"}})
+		}
+	}
+}
+
+func TestSpacesCloneRemapsLinks(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	srv.Lock()
+	setSetSource(srv)
+	for _, obj := range srv.Objects[testserver.SpaceEngineering] {
+		if obj.ID == testserver.ObjectRoadmap {
+			obj.Markdown += "\nSee [Write docs](anytype://object?objectId=" + testserver.ObjectWriteDocs + ")\n"
+		}
+	}
+	srv.Unlock()
+
+	if _, stderr, code := runCLI(t, srv, "spaces", "clone", "Engineering", "--name", "Copy"); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	copies := map[string]*anytype.Object{}
+	for _, obj := range srv.Objects["space-new-1"] {
+		copies[obj.Name] = obj
+	}
+	docs, roadmap := copies["Write docs"], copies["Roadmap"]
+	if docs == nil || roadmap == nil {
+		t.Fatalf("copied objects = %v, want Write docs and Roadmap", slices.Collect(maps.Keys(copies)))
+	}
+	if !strings.Contains(roadmap.Markdown, "objectId="+docs.ID+")") {
+		t.Errorf("roadmap body = %q, want a link to %s", roadmap.Markdown, docs.ID)
+	}
+	if len(docs.Properties) == 0 || docs.Properties[0].Select == nil || docs.Properties[0].Select.Name != "In Progress" {
+		t.Errorf("copied status = %+v, want In Progress", docs.Properties)
+	}
+
+	board := srv.Lists["space-new-1"][copies["Sprint Board"].ID]
+	if len(board.ObjectIDs) != 2 || !slices.Contains(board.ObjectIDs, docs.ID) {
+		t.Errorf("sprint board members = %v, want the copied tasks", board.ObjectIDs)
+	}
+	if tasks := srv.Lists["space-new-1"][copies["All Tasks"].ID]; !slices.Equal(tasks.SourceTypes, []string{"ot-task"}) {
+		t.Errorf("all tasks source types = %v, want [ot-task]", tasks.SourceTypes)
+	}
+}
@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+	"github.com/epheo/anytype-go"
+	"github.com/spf13/cobra"
+)
+
+// newTemplatesCmd creates the templates command
+func newTemplatesCmd(f *Factory) *cobra.Command {
+	templatesCmd := &cobra.Command{
+		Use:   "templates",
+		Short: "Manage object templates",
+		Long: `Create, update, delete and preview the templates of an object type, so that
+templates can be kept as markdown files and pushed to spaces.
+
+Use 'types templates' to list the templates of a type.`,
+	}
+
+	templatesCmd.AddCommand(
+		newTemplatesCreateCmd(f),
+		newTemplatesUpdateCmd(f),
+		newTemplatesDeleteCmd(f),
+		newTemplatesShowCmd(f),
+	)
+
+	return templatesCmd
+}
+
+// templateOptions holds the flags of the templates create and update commands
+type templateOptions struct {
+	name     string
+	bodyFile string
+	icon     string
+}
+
+// readTemplateBody reads the markdown of --body-file, '-' for the standard input. A '# '
+// heading on the first line gives the template name when --name is not set.
+func readTemplateBody(f *Factory, opts *templateOptions) (anytypecli.MarkdownDocument, error) {
+	var data []byte
+	var err error
+	if opts.bodyFile == "-" {
+		data, err = io.ReadAll(f.IO.In)
+	} else {
+		data, err = os.ReadFile(opts.bodyFile)
+	}
+	if err != nil {
+		return anytypecli.MarkdownDocument{}, clierrors.Validationf("failed to read %s: %v", opts.bodyFile, err)
+	}
+
+	doc := anytypecli.ParseMarkdown(string(data))
+	if opts.name != "" {
+		doc.Name = opts.name
+	}
+	return doc, nil
+}
+
+// newTemplatesCreateCmd creates the templates create command
+func newTemplatesCreateCmd(f *Factory) *cobra.Command {
+	opts := &templateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create [spaceID|spaceName] [typeKey|typeName]",
+		Short: "Create a template for an object type",
+		Long: `Create a template for the objects of a type, with the markdown body of
+--body-file ('-' reads standard input). The name is taken from --name, or from
+the '# ' heading on the first line of the file, which is then removed from the body:
+  anytype-cli templates create Engineering Task --body-file templates/bug.md`,
+		Args: exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			doc := anytypecli.MarkdownDocument{Name: opts.name}
+			if opts.bodyFile != "" {
+				var err error
+				if doc, err = readTemplateBody(f, opts); err != nil {
+					return err
+				}
+			}
+			if doc.Name == "" {
+				return clierrors.Validationf("template name is required (--name, or a '# ' heading in --body-file)")
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			typeKey, err := f.ResolveType(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			createReq := anytypecli.CreateTemplateRequest{
+				Name: doc.Name,
+				Body: doc.Body,
+				Icon: emojiIcon(opts.icon),
+			}
+
+			template, err := f.API().CreateTemplate(ctx, spaceID, typeKey, createReq)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to create template")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(template)
+			default:
+				f.Printf("Template '%s' (ID: %s) created for type %s.\n", template.Name, template.ID, typeKey)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name for the template (default: the '# ' heading of --body-file)")
+	cmd.Flags().StringVar(&opts.bodyFile, "body-file", "", "Markdown file with the template body, '-' for standard input")
+	cmd.Flags().StringVar(&opts.icon, "icon", "", "Emoji icon for the template")
+
+	return cmd
+}
+
+// newTemplatesUpdateCmd creates the templates update command
+func newTemplatesUpdateCmd(f *Factory) *cobra.Command {
+	opts := &templateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "update [spaceID|spaceName] [typeKey|typeName] [templateID|templateName]",
+		Short: "Update a template",
+		Long: `Update the name, icon or markdown body of a template. Only the given flags are
+changed. As with 'templates create', a '# ' heading on the first line of
+--body-file renames the template unless --name is given.`,
+		Args: exactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.name == "" && opts.bodyFile == "" && opts.icon == "" {
+				return clierrors.Validationf("nothing to update, set at least one of --name, --body-file or --icon")
+			}
+			doc := anytypecli.MarkdownDocument{Name: opts.name}
+			if opts.bodyFile != "" {
+				var err error
+				if doc, err = readTemplateBody(f, opts); err != nil {
+					return err
+				}
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			typeKey, err := f.ResolveType(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+			templateID, err := f.ResolveTemplate(cmd.Context(), spaceID, typeKey, args[2])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			updateReq := anytypecli.UpdateObjectRequest{
+				Name:     doc.Name,
+				Markdown: doc.Body,
+				Icon:     emojiIcon(opts.icon),
+			}
+
+			template, err := f.API().UpdateObject(ctx, spaceID, templateID, updateReq)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to update template")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(template)
+			default:
+				f.Printf("Template '%s' (ID: %s) updated successfully.\n", template.Name, template.ID)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "New name for the template")
+	cmd.Flags().StringVar(&opts.bodyFile, "body-file", "", "Markdown file with the new template body, '-' for standard input")
+	cmd.Flags().StringVar(&opts.icon, "icon", "", "New emoji icon for the template")
+
+	return cmd
+}
+
+// newTemplatesDeleteCmd creates the templates delete command
+func newTemplatesDeleteCmd(f *Factory) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete [spaceID|spaceName] [typeKey|typeName] [templateID|templateName]",
+		Short: "Delete a template",
+		Long: `Delete (archive) a template. Objects created from it are kept.
+
+The template is deleted after confirmation unless --yes is given. Declining the
+confirmation, or giving no answer, exits with code 9.`,
+		Args: exactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			typeKey, err := f.ResolveType(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+			templateID, err := f.ResolveTemplate(cmd.Context(), spaceID, typeKey, args[2])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			if err := confirmAction(f, yes, fmt.Sprintf("Delete template %s of type '%s'?", templateID, typeKey)); err != nil {
+				return err
+			}
+			resp, err := f.Client().Space(spaceID).Object(templateID).Delete(ctx)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to delete template")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(resp.Object)
+			default:
+				f.Printf("Template '%s' (ID: %s) deleted successfully.\n", resp.Object.Name, resp.Object.ID)
+				f.Printf("Archive status: %v\n", resp.Object.Archived)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+
+	return cmd
+}
+
+// newTemplatesShowCmd creates the templates show command
+func newTemplatesShowCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [spaceID|spaceName] [typeKey|typeName] [templateID|templateName]",
+		Short: "Print the markdown body of a template",
+		Long: `Print the markdown body of a template, as exported by Anytype. The output can
+be kept in a file and pushed back with 'templates update --body-file'.`,
+		Args: exactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			typeKey, err := f.ResolveType(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
+			templateID, err := f.ResolveTemplate(cmd.Context(), spaceID, typeKey, args[2])
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			markdown, err := anytypecli.ExportMarkdown(ctx, f.Client(), spaceID, templateID)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to export template")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON:
+				return f.PrintStructured(anytype.ExportResult{Markdown: markdown})
+			default:
+				f.Println(markdown)
+			}
+			return nil
+		},
+	}
+}
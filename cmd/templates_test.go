@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/internal/testserver"
+)
+
+func TestTemplatesCommands(t *testing.T) {
+	runCommandTests(t, []commandTest{
+		{name: "templates_create", args: []string{"templates", "create", "Engineering", "Task", "--body-file", "testdata/templates/bug.md",
+			"--icon", "🐛"}},
+		{name: "templates_update", args: []string{"templates", "update", "Engineering", "Task", "Default Task", "--name", "Definition of Done"},
+			setup: setTemplateBody},
+		{name: "templates_delete", args: []string{"templates", "delete", "Engineering", "Task", "default task", "--yes"}, setup: setTemplateBody},
+		{name: "templates_delete_unconfirmed", args: []string{"templates", "delete", "Engineering", "Task", "default task"},
+			wantCode: clierrors.ExitCancelled, formats: []string{output.FormatTable}},
+		{name: "templates_show", args: []string{"templates", "show", "Engineering", "Task", "Default Task"},
+			formats: []string{output.FormatTable, output.FormatJSON}, setup: setTemplateBody},
+		{name: "templates_create_no_name", args: []string{"templates", "create", "Engineering", "Task", "--body-file", "testdata/templates/untitled.md"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "templates_update_nothing", args: []string{"templates", "update", "Engineering", "Task", "Default Task"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "templates_show_not_found", args: []string{"templates", "show", "Engineering", "Task", "Weekly"},
+			wantCode: clierrors.ExitNotFound, formats: []string{output.FormatTable}},
+	})
+}
+
+// setTemplateBody gives the default task template a body, which the shared fixture leaves empty
+func setTemplateBody(srv *testserver.Server) {
+	srv.TemplateBodies[testserver.TemplateTaskDefault] = "## Definition of done\n\n- [ ] Tests\n- [ ] Docs\n"
+}
+
+func TestTemplatesUpdateBodyRoundTrip(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+	srv.Lock()
+	setTemplateBody(srv)
+	srv.Unlock()
+
+	if _, stderr, code := runCLI(t, srv, "templates", "update", testserver.SpaceEngineering, "ot-task", testserver.TemplateTaskDefault,
+		"--body-file", "testdata/templates/bug.md"); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	stdout, _, _ := runCLI(t, srv, "templates", "show", testserver.SpaceEngineering, "ot-task", "Bug Report")
+	if want := "## Steps to reproduce"; !strings.Contains(stdout, want) || strings.Contains(stdout, "Definition of done") {
+		t.Errorf("templates show after update:\n%s\nwant the new body", stdout)
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+	"github.com/spf13/cobra"
+)
+
+// newTrashCmd creates the trash command
+func newTrashCmd(f *Factory) *cobra.Command {
+	trashCmd := &cobra.Command{
+		Use:   "trash",
+		Short: "Inspect archived objects",
+		Long: `Inspect the archived objects of a space, which the Anytype app shows in its bin.
+Objects are archived with 'objects archive' (or 'objects delete') and recreated as
+new objects with 'objects restore'.
+
+The Anytype API cannot delete objects permanently, so the bin can only be emptied from
+the Anytype app; 'trash list --older-than' shows what emptying it would remove.`,
+	}
+
+	trashCmd.AddCommand(
+		newTrashListCmd(f),
+	)
+
+	return trashCmd
+}
+
+// newTrashListCmd creates the trash list command
+func newTrashListCmd(f *Factory) *cobra.Command {
+	var olderThan string
+
+	cmd := &cobra.Command{
+		Use:   "list [spaceID|spaceName]",
+		Short: "List the archived objects of a space",
+		Long: `List the archived objects of a space, read from the archive of the space.
+--older-than keeps the objects last modified, which includes being archived, longer
+ago than an age such as 30d, 2w or 12h.`,
+		Args:              exactArgs(1),
+		ValidArgsFunction: spaceCompletion(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var age time.Duration
+			if olderThan != "" {
+				var err error
+				if age, err = anytypecli.ParseAge(olderThan); err != nil {
+					return clierrors.Validationf("%v", err)
+				}
+			}
+
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			objects, err := f.API().ArchivedObjects(ctx, spaceID)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to list archived objects")
+			}
+			if olderThan != "" {
+				objects = anytypecli.ModifiedBefore(objects, f.Now().Add(-age))
+			}
+			return printObjects(f, objects, "Archived objects")
+		},
+	}
+
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Only list the objects archived longer ago than this age (e.g. 30d)")
+
+	return cmd
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/internal/testserver"
+)
+
+func TestTrashCommands(t *testing.T) {
+	runCommandTests(t, []commandTest{
+		{name: "trash_list", args: []string{"trash", "list", "Engineering Archive"}},
+		{name: "trash_list_older_than", args: []string{"trash", "list", "Engineering Archive", "--older-than", "30d"},
+			formats: []string{output.FormatTable}},
+		{name: "trash_list_invalid_age", args: []string{"trash", "list", "Engineering Archive", "--older-than", "a month"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+	})
+}
+
+func TestTrashListReadsArchive(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	if _, stderr, code := runCLI(t, srv, "objects", "archive", "Engineering", testserver.ObjectFixBug, "--yes"); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	stdout, stderr, code := runCLI(t, srv, "trash", "list", "Engineering")
+	if code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	if !strings.Contains(stdout, testserver.ObjectFixBug) || !strings.Contains(stdout, "Archived objects: 1") {
+		t.Errorf("stdout = %q, want the archived task only", stdout)
+	}
+
+	srv.Lock()
+	defer srv.Unlock()
+	for _, req := range srv.Requests {
+		if req.Path == "/v1/spaces/"+testserver.SpaceEngineering+"/lists/obj-archive/objects" {
+			return
+		}
+	}
+	t.Error("the archive of the space was not read")
+}
@@ -2,285 +2,461 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
+	"strings"
 	"time"
 
-	"github.com/epheo/anytype-cli/internal/auth"
-	"github.com/epheo/anytype-cli/internal/client"
+	"github.com/epheo/anytype-cli/internal/clierrors"
 	"github.com/epheo/anytype-cli/internal/output"
-	"github.com/epheo/anytype-cli/internal/spaces"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+	"github.com/epheo/anytype-go"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v3"
 )
 
-// typesCmd represents the types command
-var typesCmd = &cobra.Command{
-	Use:   "types",
-	Short: "Manage object types",
-	Long:  `List and get information about object types in an Anytype space.`,
+// newTypesCmd creates the types command
+func newTypesCmd(f *Factory) *cobra.Command {
+	typesCmd := &cobra.Command{
+		Use:   "types",
+		Short: "Manage object types",
+		Long:  `List, create, update and delete object types in an Anytype space.`,
+	}
+
+	typesCmd.AddCommand(
+		newTypesListCmd(f),
+		newTypesGetCmd(f),
+		newTypesCreateCmd(f),
+		newTypesUpdateCmd(f),
+		newTypesDeleteCmd(f),
+		newTemplatesListCmd(f),
+		newTemplatesGetCmd(f),
+	)
+
+	return typesCmd
 }
 
-// typesListCmd represents the types list command
-var typesListCmd = &cobra.Command{
-	Use:   "list [spaceID|spaceName]",
-	Short: "List all object types in a space",
-	Long:  `List all available object types in the specified Anytype space.`,
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// newTypesListCmd creates the types list command
+func newTypesListCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [spaceID|spaceName]",
+		Short: "List all object types in a space",
+		Long:  `List all available object types in the specified Anytype space.`,
+		Args:  exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
-		}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+			types, err := f.Client().Space(spaceID).Types().List(ctx)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to list object types")
+			}
 
-		anytypeClient := client.GetClient(cfg)
-		types, err := anytypeClient.Space(spaceID).Types().List(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to list object types: %v\n", err)
-			os.Exit(1)
-		}
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(types)
+			default:
+				// Table format with dynamic column widths
+				table := output.NewTable([]string{"KEY", "NAME", "LAYOUT", "DESCRIPTION"})
+				for _, typ := range types {
+					table.AddRow([]string{typ.Key, typ.Name, typ.RecommendedLayout, typ.Description})
+				}
+				f.Print(table.String())
+				f.Printf("\nTotal types: %d\n", len(types))
+			}
+			return nil
+		},
+	}
+}
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(types, "", "  ")
+// newTypesGetCmd creates the types get command
+func newTypesGetCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [spaceID|spaceName] [typeID]",
+		Short: "Get details of a specific object type",
+		Long:  `Retrieve detailed information about a specific object type in an Anytype space.`,
+		Args:  exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(types)
+
+			typeID := args[1]
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			resp, err := f.Client().Space(spaceID).Type(typeID).Get(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return clierrors.Wrap(err, "failed to get type details")
 			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Table format with dynamic column widths
-			table := output.NewTable([]string{"KEY", "NAME", "LAYOUT", "DESCRIPTION"})
-			for _, typ := range types {
-				table.AddRow([]string{typ.Key, typ.Name, typ.RecommendedLayout, typ.Description})
+
+			return printType(f, &resp.Type)
+		},
+	}
+}
+
+// printType writes the details of an object type in the selected output format
+func printType(f *Factory, typ *anytype.Type) error {
+	switch f.OutputFormat {
+	case output.FormatJSON, output.FormatYAML:
+		return f.PrintStructured(typ)
+	default:
+		// Detailed output
+		f.Println("TYPE DETAILS")
+		f.Println("------------")
+		f.Printf("Key: %s\n", typ.Key)
+		f.Printf("Name: %s\n", typ.Name)
+		f.Printf("Description: %s\n", typ.Description)
+		f.Printf("Layout: %s\n", typ.Layout)
+		f.Printf("Recommended Layout: %s\n", typ.RecommendedLayout)
+		f.Printf("Is Archived: %v\n", typ.IsArchived)
+		f.Printf("Is Hidden: %v\n", typ.IsHidden)
+
+		if len(typ.PropertyDefinitions) > 0 {
+			f.Println("\nPROPERTY DEFINITIONS")
+			f.Println("-------------------")
+			f.Println("KEY                    NAME                   FORMAT")
+			f.Println("---------------------- ---------------------- ----------------")
+			for _, prop := range typ.PropertyDefinitions {
+				f.Printf("%-20s  %-20s  %-12s\n",
+					output.Truncate(prop.Key, 20),
+					output.Truncate(prop.Name, 20),
+					output.Truncate(prop.Format, 12))
 			}
-			fmt.Print(table.String())
-			fmt.Printf("\nTotal types: %d\n", len(types))
 		}
-	},
+	}
+	return nil
 }
 
-// typesGetCmd represents the types get command
-var typesGetCmd = &cobra.Command{
-	Use:   "get [spaceID|spaceName] [typeID]",
-	Short: "Get details of a specific object type",
-	Long:  `Retrieve detailed information about a specific object type in an Anytype space.`,
-	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// newTemplatesListCmd creates the templates list command
+func newTemplatesListCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "templates [spaceID|spaceName] [typeID]",
+		Short: "List templates for an object type",
+		Long:  `List all available templates for the specified object type in an Anytype space.`,
+		Args:  exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
-		}
+			typeID := args[1]
 
-		typeID := args[1]
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+			templates, err := f.Client().Space(spaceID).Type(typeID).Templates().List(ctx)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to list templates")
+			}
 
-		anytypeClient := client.GetClient(cfg)
-		resp, err := anytypeClient.Space(spaceID).Type(typeID).Get(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to get type details: %v\n", err)
-			os.Exit(1)
-		}
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(templates)
+			default:
+				// Table format with dynamic column widths
+				table := output.NewTable([]string{"TEMPLATE ID", "NAME", "ARCHIVED"})
+				for _, template := range templates {
+					table.AddRow([]string{template.ID, template.Name, fmt.Sprintf("%v", template.Archived)})
+				}
+				f.Print(table.String())
+				f.Printf("\nTotal templates: %d\n", len(templates))
+			}
+			return nil
+		},
+	}
+}
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Type, "", "  ")
+// newTemplatesGetCmd creates the templates get command
+func newTemplatesGetCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "template-get [spaceID|spaceName] [typeID] [templateID]",
+		Short: "Get details of a specific template",
+		Long:  `Retrieve detailed information about a specific template for an object type.`,
+		Args:  exactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Type)
+
+			typeID := args[1]
+			templateID := args[2]
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			resp, err := f.Client().Space(spaceID).Type(typeID).Template(templateID).Get(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return clierrors.Wrap(err, "failed to get template details")
 			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Detailed output
-			typ := resp.Type
-			fmt.Println("TYPE DETAILS")
-			fmt.Println("------------")
-			fmt.Printf("Key: %s\n", typ.Key)
-			fmt.Printf("Name: %s\n", typ.Name)
-			fmt.Printf("Description: %s\n", typ.Description)
-			fmt.Printf("Layout: %s\n", typ.Layout)
-			fmt.Printf("Recommended Layout: %s\n", typ.RecommendedLayout)
-			fmt.Printf("Is Archived: %v\n", typ.IsArchived)
-			fmt.Printf("Is Hidden: %v\n", typ.IsHidden)
-
-			if len(typ.PropertyDefinitions) > 0 {
-				fmt.Println("\nPROPERTY DEFINITIONS")
-				fmt.Println("-------------------")
-				fmt.Println("KEY                    NAME                   FORMAT")
-				fmt.Println("---------------------- ---------------------- ----------------")
-				for _, prop := range typ.PropertyDefinitions {
-					fmt.Printf("%-20s  %-20s  %-12s\n",
-						output.Truncate(prop.Key, 20),
-						output.Truncate(prop.Name, 20),
-						output.Truncate(prop.Format, 12))
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(resp.Template)
+			default:
+				// Detailed output
+				template := resp.Template
+				f.Println("TEMPLATE DETAILS")
+				f.Println("----------------")
+				f.Printf("ID: %s\n", template.ID)
+				f.Printf("Name: %s\n", template.Name)
+				f.Printf("Archived: %v\n", template.Archived)
+				if template.Icon != nil {
+					if template.Icon.Format == "emoji" {
+						f.Printf("Icon: %s\n", template.Icon.Emoji)
+					} else {
+						f.Printf("Icon: %s (%s)\n", template.Icon.Name, template.Icon.Format)
+					}
 				}
 			}
-		}
-	},
+			return nil
+		},
+	}
 }
 
-// templatesListCmd represents the templates list command
-var templatesListCmd = &cobra.Command{
-	Use:   "templates [spaceID|spaceName] [typeID]",
-	Short: "List templates for an object type",
-	Long:  `List all available templates for the specified object type in an Anytype space.`,
-	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+// typesCreateOptions holds the flags of the types create command
+type typesCreateOptions struct {
+	name       string
+	key        string
+	layout     string
+	icon       string
+	pluralName string
+	properties []string
+}
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
-		}
+// newTypesCreateCmd creates the types create command
+func newTypesCreateCmd(f *Factory) *cobra.Command {
+	opts := &typesCreateOptions{}
 
-		typeID := args[1]
+	cmd := &cobra.Command{
+		Use:   "create [spaceID|spaceName]",
+		Short: "Create a new object type",
+		Long: `Create a new object type in the specified Anytype space.
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+Properties are given as key:format or key:format:Name, for example:
+  anytype-cli types create Engineering --name Incident --layout action \
+    --property severity:select:Severity --property resolved_at:date`,
+		Args: exactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.name == "" {
+				return clierrors.Validationf("type name is required")
+			}
+			if !anytypecli.ValidTypeLayout(opts.layout) {
+				return clierrors.Validationf("invalid layout '%s' (expected one of: %s)",
+					opts.layout, strings.Join(anytypecli.TypeLayouts, ", "))
+			}
+			properties, err := parsePropertyDefinitions(opts.properties)
+			if err != nil {
+				return err
+			}
 
-		anytypeClient := client.GetClient(cfg)
-		templates, err := anytypeClient.Space(spaceID).Type(typeID).Templates().List(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to list templates: %v\n", err)
-			os.Exit(1)
-		}
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			createReq := anytype.CreateTypeRequest{
+				Key:        opts.key,
+				Name:       opts.name,
+				Layout:     opts.layout,
+				PluralName: opts.pluralName,
+				Icon:       emojiIcon(opts.icon),
+				Properties: properties,
+			}
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(templates, "", "  ")
+			resp, err := f.Client().Space(spaceID).Types().Create(ctx, createReq)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return clierrors.Wrap(err, "failed to create type")
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(templates)
+
+			return printType(f, &resp.Type)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Name for the new type (required)")
+	cmd.Flags().StringVar(&opts.key, "key", "", "Key for the new type (default: generated by Anytype)")
+	cmd.Flags().StringVar(&opts.layout, "layout", "basic", fmt.Sprintf("Layout of the type's objects (%s)", strings.Join(anytypecli.TypeLayouts, ", ")))
+	cmd.Flags().StringVar(&opts.icon, "icon", "", "Emoji icon for the type (e.g. '🐞')")
+	cmd.Flags().StringVar(&opts.pluralName, "plural-name", "", "Plural name for the type")
+	cmd.Flags().StringArrayVar(&opts.properties, "property", nil, "Property definition as key:format[:Name] (repeatable)")
+	cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+// typesUpdateOptions holds the flags of the types update command
+type typesUpdateOptions struct {
+	name             string
+	layout           string
+	icon             string
+	pluralName       string
+	addProperties    []string
+	removeProperties []string
+}
+
+// newTypesUpdateCmd creates the types update command
+func newTypesUpdateCmd(f *Factory) *cobra.Command {
+	opts := &typesUpdateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "update [spaceID|spaceName] [typeKey|typeName]",
+		Short: "Update an object type",
+		Long: `Update the name, layout or icon of an object type, and add or remove
+property definitions. Only the given flags are changed.
+
+Added properties are given as key:format or key:format:Name; adding a key the
+type already has replaces its definition.`,
+		Args: exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.layout != "" && !anytypecli.ValidTypeLayout(opts.layout) {
+				return clierrors.Validationf("invalid layout '%s' (expected one of: %s)",
+					opts.layout, strings.Join(anytypecli.TypeLayouts, ", "))
+			}
+			add, err := parsePropertyDefinitions(opts.addProperties)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Table format with dynamic column widths
-			table := output.NewTable([]string{"TEMPLATE ID", "NAME", "ARCHIVED"})
-			for _, template := range templates {
-				table.AddRow([]string{template.ID, template.Name, fmt.Sprintf("%v", template.Archived)})
+			if opts.name == "" && opts.layout == "" && opts.icon == "" && opts.pluralName == "" &&
+				len(add) == 0 && len(opts.removeProperties) == 0 {
+				return clierrors.Validationf("nothing to update, set at least one of --name, --layout, --icon, --plural-name, --add-property or --remove-property")
 			}
-			fmt.Print(table.String())
-			fmt.Printf("\nTotal templates: %d\n", len(templates))
-		}
-	},
-}
 
-// templatesGetCmd represents the templates get command
-var templatesGetCmd = &cobra.Command{
-	Use:   "template-get [spaceID|spaceName] [typeID] [templateID]",
-	Short: "Get details of a specific template",
-	Long:  `Retrieve detailed information about a specific template for an object type.`,
-	Args:  cobra.ExactArgs(3),
-	Run: func(cmd *cobra.Command, args []string) {
-		if !auth.IsAuthenticated(cfg) {
-			fmt.Println("You are not authenticated. Please run 'anytype-cli auth' first.")
-			os.Exit(1)
-		}
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
+			if err != nil {
+				return err
+			}
+			typeKey, err := f.ResolveType(cmd.Context(), spaceID, args[1])
+			if err != nil {
+				return err
+			}
 
-		spaceIdOrName := args[0]
-		spaceID, err := spaces.ResolveSpace(cfg, spaceIdOrName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to resolve space: %v\n", err)
-			os.Exit(1)
-		}
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
 
-		typeID := args[1]
-		templateID := args[2]
+			updateReq := anytypecli.UpdateTypeRequest{
+				Name:       opts.name,
+				Layout:     opts.layout,
+				PluralName: opts.pluralName,
+				Icon:       emojiIcon(opts.icon),
+			}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+			if len(add) > 0 || len(opts.removeProperties) > 0 {
+				resp, err := f.Client().Space(spaceID).Type(typeKey).Get(ctx)
+				if err != nil {
+					return clierrors.Wrap(err, "failed to get type details")
+				}
+				properties, err := anytypecli.MergePropertyDefinitions(resp.Type.PropertyDefinitions, add, opts.removeProperties)
+				if err != nil {
+					return clierrors.Validationf("%v", err)
+				}
+				updateReq.Properties = &properties
+			}
 
-		anytypeClient := client.GetClient(cfg)
-		resp, err := anytypeClient.Space(spaceID).Type(typeID).Template(templateID).Get(ctx)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to get template details: %v\n", err)
-			os.Exit(1)
-		}
+			typ, err := f.API().UpdateType(ctx, spaceID, typeKey, updateReq)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to update type")
+			}
+
+			return printType(f, typ)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "New name for the type")
+	cmd.Flags().StringVar(&opts.layout, "layout", "", fmt.Sprintf("New layout of the type's objects (%s)", strings.Join(anytypecli.TypeLayouts, ", ")))
+	cmd.Flags().StringVar(&opts.icon, "icon", "", "New emoji icon for the type")
+	cmd.Flags().StringVar(&opts.pluralName, "plural-name", "", "New plural name for the type")
+	cmd.Flags().StringArrayVar(&opts.addProperties, "add-property", nil, "Property definition to add, as key:format[:Name] (repeatable)")
+	cmd.Flags().StringArrayVar(&opts.removeProperties, "remove-property", nil, "Key of a property definition to remove (repeatable)")
+
+	return cmd
+}
+
+// newTypesDeleteCmd creates the types delete command
+func newTypesDeleteCmd(f *Factory) *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete [spaceID|spaceName] [typeKey|typeName]",
+		Short: "Delete an object type",
+		Long: `Delete (archive) an object type. Objects of this type are kept.
 
-		switch outputFormat {
-		case "json":
-			jsonOutput, err := json.MarshalIndent(resp.Template, "", "  ")
+The type is deleted after confirmation unless --yes is given. Declining the
+confirmation, or giving no answer, exits with code 9.`,
+		Args: exactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spaceIdOrName := args[0]
+			spaceID, err := f.ResolveSpace(cmd.Context(), spaceIdOrName)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(jsonOutput))
-		case "yaml":
-			yamlOutput, err := yaml.Marshal(resp.Template)
+			typeKey, err := f.ResolveType(cmd.Context(), spaceID, args[1])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to format output: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Println(string(yamlOutput))
-		default:
-			// Detailed output
-			template := resp.Template
-			fmt.Println("TEMPLATE DETAILS")
-			fmt.Println("----------------")
-			fmt.Printf("ID: %s\n", template.ID)
-			fmt.Printf("Name: %s\n", template.Name)
-			fmt.Printf("Archived: %v\n", template.Archived)
-			if template.Icon != nil {
-				if template.Icon.Format == "emoji" {
-					fmt.Printf("Icon: %s\n", template.Icon.Emoji)
-				} else {
-					fmt.Printf("Icon: %s (%s)\n", template.Icon.Name, template.Icon.Format)
-				}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			if err := confirmAction(f, yes, fmt.Sprintf("Delete type '%s'?", typeKey)); err != nil {
+				return err
+			}
+			typ, err := f.API().DeleteType(ctx, spaceID, typeKey)
+			if err != nil {
+				return clierrors.Wrap(err, "failed to delete type")
+			}
+
+			switch f.OutputFormat {
+			case output.FormatJSON, output.FormatYAML:
+				return f.PrintStructured(typ)
+			default:
+				f.Printf("Type '%s' (Key: %s) deleted successfully.\n", typ.Name, typ.Key)
+				f.Printf("Archive status: %v\n", typ.IsArchived)
 			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for confirmation")
+
+	return cmd
+}
+
+// parsePropertyDefinitions parses key:format[:Name] flag values
+func parsePropertyDefinitions(values []string) ([]anytype.PropertyDefinition, error) {
+	var defs []anytype.PropertyDefinition
+	for _, value := range values {
+		def, err := anytypecli.ParsePropertyDefinition(value)
+		if err != nil {
+			return nil, clierrors.Validationf("%v", err)
 		}
-	},
+		defs = append(defs, def)
+	}
+	return defs, nil
 }
 
-func init() {
-	rootCmd.AddCommand(typesCmd)
-	typesCmd.AddCommand(typesListCmd)
-	typesCmd.AddCommand(typesGetCmd)
-	typesCmd.AddCommand(templatesListCmd)
-	typesCmd.AddCommand(templatesGetCmd)
+// emojiIcon returns an emoji icon, or nil if emoji is empty
+func emojiIcon(emoji string) *anytype.Icon {
+	if emoji == "" {
+		return nil
+	}
+	return &anytype.Icon{
+		Format: anytype.IconFormatEmoji,
+		Emoji:  emoji,
+	}
 }
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/internal/clierrors"
+	"github.com/epheo/anytype-cli/internal/output"
+	"github.com/epheo/anytype-cli/internal/testserver"
+)
+
+func TestTypesCommands(t *testing.T) {
+	runCommandTests(t, []commandTest{
+		{name: "types_list", args: []string{"types", "list", "Engineering"}},
+		{name: "types_get", args: []string{"types", "get", "Engineering", "ot-task"}},
+		{name: "types_templates", args: []string{"types", "templates", "Engineering", "ot-task"}},
+		{name: "types_template_get", args: []string{"types", "template-get", "Engineering", "ot-task", testserver.TemplateTaskDefault}},
+		{name: "types_create", args: []string{"types", "create", "Engineering", "--name", "Incident", "--key", "incident",
+			"--layout", "action", "--icon", "🔥", "--property", "severity:select:Severity", "--property", "resolved_at:date"}},
+		{name: "types_update", args: []string{"types", "update", "Engineering", "Task", "--name", "Work item",
+			"--add-property", "estimate:number:Estimate", "--remove-property", "done"}},
+		{name: "types_delete", args: []string{"types", "delete", "Engineering", "ot-task", "--yes"}},
+		{name: "types_delete_unconfirmed", args: []string{"types", "delete", "Engineering", "ot-task"},
+			wantCode: clierrors.ExitCancelled, formats: []string{output.FormatTable}},
+		{name: "types_create_invalid_layout", args: []string{"types", "create", "Engineering", "--name", "X", "--layout", "grid"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "types_create_invalid_format", args: []string{"types", "create", "Engineering", "--name", "X", "--property", "size:bigint"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "types_update_unknown_property", args: []string{"types", "update", "Engineering", "ot-task", "--remove-property", "missing"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatTable}},
+		{name: "types_update_nothing", args: []string{"types", "update", "Engineering", "ot-task"},
+			wantCode: clierrors.ExitValidation, formats: []string{output.FormatJSON}},
+	})
+}
+
+func TestTypesDeleteHidesType(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+
+	if _, stderr, code := runCLI(t, srv, "types", "delete", testserver.SpaceEngineering, "ot-task", "--yes"); code != clierrors.ExitOK {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+
+	stdout, _, _ := runCLI(t, srv, "types", "list", testserver.SpaceEngineering)
+	if want := "Total types: 2"; !strings.Contains(stdout, want) {
+		t.Errorf("types list after delete:\n%s\nwant %q", stdout, want)
+	}
+}
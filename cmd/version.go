@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"fmt"
-
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
 	"github.com/epheo/anytype-go"
 	"github.com/spf13/cobra"
 )
@@ -13,21 +12,20 @@ const (
 	AppName    = "anytype-cli"
 )
 
-// versionCmd represents the version command
-var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "Display version information",
-	Long:  `Display version information for the CLI and the Anytype SDK it's using.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		// Get SDK version info
-		sdkVersion := anytype.GetVersionInfo()
-
-		fmt.Printf("%s version: %s\n", AppName, AppVersion)
-		fmt.Printf("Anytype SDK version: %s\n", sdkVersion.Version)
-		fmt.Printf("Anytype API version: %s\n", sdkVersion.APIVersion)
-	},
-}
+// newVersionCmd creates the version command
+func newVersionCmd(f *Factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Display version information",
+		Long:  `Display version information for the CLI and the Anytype SDK it's using.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// Get SDK version info
+			sdkVersion := anytype.GetVersionInfo()
 
-func init() {
-	rootCmd.AddCommand(versionCmd)
+			f.Printf("%s version: %s\n", AppName, AppVersion)
+			f.Printf("anytypecli library version: %s\n", anytypecli.Version)
+			f.Printf("Anytype SDK version: %s\n", sdkVersion.Version)
+			f.Printf("Anytype API version: %s\n", sdkVersion.APIVersion)
+		},
+	}
 }
@@ -3,15 +3,17 @@ package auth
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
-	"github.com/AlecAivazis/survey/v2"
 	"github.com/epheo/anytype-cli/internal/config"
 	"github.com/epheo/anytype-go"
 )
 
-// RunAuthentication performs the interactive authentication flow
-func RunAuthentication(baseURL string) (*config.Config, error) {
+// RunAuthentication performs the interactive authentication flow. Instructions are
+// written to out and the verification code is read with prompt, which is given the
+// question to ask.
+func RunAuthentication(baseURL string, out io.Writer, prompt func(question string) (string, error)) (*config.Config, error) {
 	// Create unauthenticated client
 	client := anytype.NewClient(
 		anytype.WithBaseURL(baseURL),
@@ -22,39 +24,37 @@ func RunAuthentication(baseURL string) (*config.Config, error) {
 	defer cancel()
 
 	// Step 1: Initiate auth flow and get challenge ID
-	fmt.Println("Starting authentication with Anytype...")
-	authResponse, err := client.Auth().DisplayCode(ctx, "anytype-cli")
+	fmt.Fprintln(out, "Starting authentication with Anytype...")
+	authResponse, err := client.Auth().CreateChallenge(ctx, "anytype-cli")
 	if err != nil {
 		return nil, fmt.Errorf("failed to initiate authentication: %w", err)
 	}
 	challengeID := authResponse.ChallengeID
 
 	// Step 2: Prompt user to enter verification code
-	fmt.Println("\nPlease check your Anytype app and enter the displayed verification code.")
-	fmt.Println("The code should be visible in your Anytype app's authentication screen.")
-	fmt.Println("If no code appears, make sure Anytype is running and try again.")
+	fmt.Fprintln(out, "\nPlease check your Anytype app and enter the displayed verification code.")
+	fmt.Fprintln(out, "The code should be visible in your Anytype app's authentication screen.")
+	fmt.Fprintln(out, "If no code appears, make sure Anytype is running and try again.")
 
-	var code string
-	prompt := &survey.Input{
-		Message: "Enter verification code:",
-	}
-	if err := survey.AskOne(prompt, &code); err != nil {
+	code, err := prompt("Enter verification code: ")
+	if err != nil {
 		return nil, fmt.Errorf("authentication canceled: %w", err)
 	}
+	if code == "" {
+		return nil, fmt.Errorf("authentication canceled: no verification code given")
+	}
 
 	// Step 3: Complete auth by providing the code
-	tokenResponse, err := client.Auth().GetToken(ctx, challengeID, code)
+	tokenResponse, err := client.Auth().CreateApiKey(ctx, challengeID, code)
 	if err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
 	}
 
 	// Save tokens to config
 	cfg := &config.Config{
-		AppKey:  tokenResponse.AppKey,
+		AppKey:  tokenResponse.ApiKey,
 		BaseURL: baseURL,
 	}
-
-	fmt.Println("Authentication successful!")
 	return cfg, nil
 }
 
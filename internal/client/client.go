@@ -1,15 +1,43 @@
 package client
 
 import (
+	"io"
+	"net/http"
+
 	"github.com/epheo/anytype-cli/internal/config"
 	"github.com/epheo/anytype-go"
 	_ "github.com/epheo/anytype-go/client" // Register client implementation
 )
 
-// GetClient returns an authenticated Anytype client using the stored configuration
-func GetClient(cfg *config.Config) anytype.Client {
+// NewClient returns an authenticated Anytype client using the given configuration.
+// API call tracing, if enabled, is written to traceOut.
+func NewClient(cfg *config.Config, traceOut io.Writer) anytype.Client {
+	EnableTracing(cfg, traceOut)
 	return anytype.NewClient(
 		anytype.WithBaseURL(cfg.BaseURL),
 		anytype.WithAppKey(cfg.AppKey),
 	)
 }
+
+// EnableTracing installs a TracingTransport writing to out when verbose or HTTP debug
+// output is requested. The SDK always sends its requests through http.DefaultClient,
+// so the transport is injected there. Calling it more than once replaces the previous tracer.
+func EnableTracing(cfg *config.Config, out io.Writer) {
+	base := http.DefaultClient.Transport
+	if tracer, ok := base.(*TracingTransport); ok {
+		base = tracer.Base
+	}
+
+	if !cfg.Verbose && !cfg.DebugHTTP {
+		http.DefaultClient.Transport = base
+		return
+	}
+
+	http.DefaultClient.Transport = &TracingTransport{
+		Base:       base,
+		Out:        out,
+		Verbose:    cfg.Verbose,
+		DumpBodies: cfg.DebugHTTP,
+		Secrets:    []string{cfg.AppKey},
+	}
+}
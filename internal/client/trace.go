@@ -0,0 +1,146 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// redacted replaces any secret value in traced output
+const redacted = "[REDACTED]"
+
+// secretFieldPattern matches JSON fields that carry credentials in auth responses
+var secretFieldPattern = regexp.MustCompile(`"(app_key|api_key)"\s*:\s*"[^"]*"`)
+
+// TracingTransport is an http.RoundTripper that logs every API call.
+// With Verbose set it writes one line per request (method, path, status, latency);
+// with DumpBodies set it writes the full request and response, headers and bodies,
+// with the app key redacted. Both may be set.
+type TracingTransport struct {
+	Base       http.RoundTripper
+	Out        io.Writer
+	Verbose    bool
+	DumpBodies bool
+	// Secrets lists values that must never appear in the output (e.g. the app key)
+	Secrets []string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if t.DumpBodies {
+		body, err := drainBody(&req.Body)
+		if err != nil {
+			return nil, err
+		}
+		t.dumpRequest(req, body)
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+
+	if err != nil {
+		if t.Verbose {
+			fmt.Fprintf(t.Out, "[http] %s %s -> error: %v (%s)\n", req.Method, req.URL.Path, err, elapsed)
+		}
+		if t.DumpBodies {
+			fmt.Fprintf(t.Out, "< error: %v\n\n", err)
+		}
+		return nil, err
+	}
+
+	if t.Verbose {
+		fmt.Fprintf(t.Out, "[http] %s %s -> %d (%s)\n", req.Method, req.URL.Path, resp.StatusCode, elapsed)
+	}
+
+	if t.DumpBodies {
+		body, err := drainBody(&resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		t.dumpResponse(resp, body)
+	}
+
+	return resp, nil
+}
+
+// dumpRequest writes the request line, headers and body
+func (t *TracingTransport) dumpRequest(req *http.Request, body []byte) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "> %s %s\n", req.Method, req.URL.RequestURI())
+	writeHeaders(&b, "> ", req.Header)
+	writeBody(&b, body)
+	io.WriteString(t.Out, t.redact(b.String()))
+}
+
+// dumpResponse writes the status line, headers and body
+func (t *TracingTransport) dumpResponse(resp *http.Response, body []byte) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "< %s\n", resp.Status)
+	writeHeaders(&b, "< ", resp.Header)
+	writeBody(&b, body)
+	io.WriteString(t.Out, t.redact(b.String()))
+}
+
+// redact removes the app key and any credential fields from the traced text
+func (t *TracingTransport) redact(s string) string {
+	for _, secret := range t.Secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, redacted)
+		}
+	}
+	return secretFieldPattern.ReplaceAllString(s, fmt.Sprintf(`"$1": "%s"`, redacted))
+}
+
+// writeHeaders writes headers in a stable order, hiding the Authorization value
+func writeHeaders(b *strings.Builder, prefix string, header http.Header) {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range header[key] {
+			if strings.EqualFold(key, "Authorization") {
+				value = "Bearer " + redacted
+			}
+			fmt.Fprintf(b, "%s%s: %s\n", prefix, key, value)
+		}
+	}
+}
+
+// writeBody writes the body followed by a blank separator line
+func writeBody(b *strings.Builder, body []byte) {
+	if len(body) > 0 {
+		b.Write(body)
+		if body[len(body)-1] != '\n' {
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+}
+
+// drainBody reads the body fully and replaces it with an equivalent reader
+func drainBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
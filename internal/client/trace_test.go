@@ -0,0 +1,80 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTracingTransportRedactsSecrets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"api_key": "secret-key", "name": "ok"}`)
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	client := &http.Client{Transport: &TracingTransport{
+		Out:        &out,
+		Verbose:    true,
+		DumpBodies: true,
+		Secrets:    []string{"secret-key"},
+	}}
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/v1/auth/api_keys", strings.NewReader(`{"code":"1234"}`))
+	req.Header.Set("Authorization", "Bearer secret-key")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if !strings.Contains(string(body), "secret-key") {
+		t.Error("response body seen by the caller must not be altered")
+	}
+
+	trace := out.String()
+	if strings.Contains(trace, "secret-key") {
+		t.Errorf("trace leaks the app key:\n%s", trace)
+	}
+	for _, want := range []string{"[http] POST /v1/auth/api_keys -> 200", "> Authorization: Bearer [REDACTED]", `{"code":"1234"}`, `"name": "ok"`} {
+		if !strings.Contains(trace, want) {
+			t.Errorf("trace is missing %q:\n%s", want, trace)
+		}
+	}
+}
+
+func TestTracingTransportVerbose(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"name": "ok"}`)
+	}))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		verbose, dumpBodies bool
+		wantLine, wantDump  bool
+	}{
+		{verbose: true, wantLine: true},
+		{dumpBodies: true, wantDump: true},
+		{verbose: true, dumpBodies: true, wantLine: true, wantDump: true},
+	} {
+		var out bytes.Buffer
+		client := &http.Client{Transport: &TracingTransport{Out: &out, Verbose: tc.verbose, DumpBodies: tc.dumpBodies}}
+		resp, err := client.Get(srv.URL + "/v1/spaces")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		trace := out.String()
+		if got := strings.Contains(trace, "[http] GET /v1/spaces -> 200"); got != tc.wantLine {
+			t.Errorf("verbose=%v dumpBodies=%v: request line written = %v, want %v:\n%s", tc.verbose, tc.dumpBodies, got, tc.wantLine, trace)
+		}
+		if got := strings.Contains(trace, "> GET /v1/spaces"); got != tc.wantDump {
+			t.Errorf("verbose=%v dumpBodies=%v: request dumped = %v, want %v:\n%s", tc.verbose, tc.dumpBodies, got, tc.wantDump, trace)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+// Package clierrors defines the typed errors returned by commands and the exit code
+// associated with each class of failure.
+package clierrors
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+)
+
+// Exit codes returned by the CLI, one per error class
+const (
+	ExitOK                = 0
+	ExitGeneric           = 1
+	ExitValidation        = 2
+	ExitNotAuthenticated  = 3
+	ExitNotFound          = 4
+	ExitAmbiguousName     = 5
+	ExitServerUnreachable = 6
+	ExitAPI               = 7
+	ExitDifferences       = 8
+	ExitCancelled         = 9
+	ExitPartialFailure    = 10
+)
+
+// Error kinds, as reported in JSON error output
+const (
+	KindGeneric           = "error"
+	KindValidation        = "validation"
+	KindNotAuthenticated  = "not_authenticated"
+	KindNotFound          = "not_found"
+	KindAmbiguousName     = "ambiguous_name"
+	KindServerUnreachable = "server_unreachable"
+	KindAPI               = "api_error"
+	KindDifferences       = "differences"
+	KindCancelled         = "cancelled"
+	KindPartialFailure    = "partial_failure"
+)
+
+// CLIError is implemented by every typed error in this package
+type CLIError interface {
+	error
+	// Kind returns the machine-readable error class
+	Kind() string
+	// ExitCode returns the process exit code for this error class
+	ExitCode() int
+}
+
+// NotAuthenticatedError indicates that no app key is configured or the API rejected it
+type NotAuthenticatedError struct {
+	Message string
+}
+
+func (e *NotAuthenticatedError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return "you are not authenticated, run 'anytype-cli auth' first"
+}
+
+func (e *NotAuthenticatedError) Kind() string  { return KindNotAuthenticated }
+func (e *NotAuthenticatedError) ExitCode() int { return ExitNotAuthenticated }
+
+// NotFoundError indicates that a referenced resource does not exist
+type NotFoundError struct {
+	Resource string
+	ID       string
+	Message  string
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s '%s' not found", e.Resource, e.ID)
+}
+
+func (e *NotFoundError) Kind() string  { return KindNotFound }
+func (e *NotFoundError) ExitCode() int { return ExitNotFound }
+
+// AmbiguousNameError indicates that a name matched more than one resource
+type AmbiguousNameError struct {
+	Resource string
+	Name     string
+	// Matches lists the candidates as "name (ID: id)"
+	Matches []string
+}
+
+func (e *AmbiguousNameError) Error() string {
+	msg := fmt.Sprintf("multiple %ss matched '%s', please use an ID or a more specific name. Matched %ss:",
+		e.Resource, e.Name, e.Resource)
+	for i, match := range e.Matches {
+		if i < 5 { // Limit to first 5 matches to avoid overwhelming output
+			msg += "\n  - " + match
+		}
+	}
+	if len(e.Matches) > 5 {
+		msg += fmt.Sprintf("\n  ... and %d more", len(e.Matches)-5)
+	}
+	return msg
+}
+
+func (e *AmbiguousNameError) Kind() string  { return KindAmbiguousName }
+func (e *AmbiguousNameError) ExitCode() int { return ExitAmbiguousName }
+
+// ValidationError indicates invalid user input (flags, arguments, files)
+type ValidationError struct {
+	Message string
+}
+
+func (e *ValidationError) Error() string { return e.Message }
+func (e *ValidationError) Kind() string  { return KindValidation }
+func (e *ValidationError) ExitCode() int { return ExitValidation }
+
+// ServerUnreachableError indicates that the Anytype API could not be reached
+type ServerUnreachableError struct {
+	Message string
+	Err     error
+}
+
+func (e *ServerUnreachableError) Error() string {
+	return fmt.Sprintf("%s: %v (is the Anytype app running with the API enabled?)", e.Message, e.Err)
+}
+
+func (e *ServerUnreachableError) Unwrap() error { return e.Err }
+func (e *ServerUnreachableError) Kind() string  { return KindServerUnreachable }
+func (e *ServerUnreachableError) ExitCode() int { return ExitServerUnreachable }
+
+// APIError indicates that the Anytype API answered with a non-2xx status
+type APIError struct {
+	Message string
+	Status  int
+	Body    string
+}
+
+func (e *APIError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("%s: API returned status %d", e.Message, e.Status)
+	}
+	return fmt.Sprintf("%s: API returned status %d: %s", e.Message, e.Status, e.Body)
+}
+
+func (e *APIError) Kind() string  { return KindAPI }
+func (e *APIError) ExitCode() int { return ExitAPI }
+
+// DifferencesError indicates that a comparison found differences, so that scripts can
+// gate on the exit code
+type DifferencesError struct {
+	Message string
+}
+
+func (e *DifferencesError) Error() string { return e.Message }
+func (e *DifferencesError) Kind() string  { return KindDifferences }
+func (e *DifferencesError) ExitCode() int { return ExitDifferences }
+
+// CancelledError indicates that a confirmation was declined, or could not be asked
+// because the input ended
+type CancelledError struct {
+	Message string
+}
+
+func (e *CancelledError) Error() string { return e.Message }
+func (e *CancelledError) Kind() string  { return KindCancelled }
+func (e *CancelledError) ExitCode() int { return ExitCancelled }
+
+// PartialFailureError indicates that an operation on several objects failed for some of
+// them only; the outcome of each object is reported by the command
+type PartialFailureError struct {
+	Message string
+}
+
+func (e *PartialFailureError) Error() string { return e.Message }
+func (e *PartialFailureError) Kind() string  { return KindPartialFailure }
+func (e *PartialFailureError) ExitCode() int { return ExitPartialFailure }
+
+// Validationf returns a ValidationError with a formatted message
+func Validationf(format string, args ...interface{}) error {
+	return &ValidationError{Message: fmt.Sprintf(format, args...)}
+}
+
+// statusPattern extracts the status and body from SDK request errors
+var statusPattern = regexp.MustCompile(`request failed with status (\d+): ?(.*)`)
+
+// Wrap classifies an error returned by the SDK and prefixes it with msg.
+// Errors that are already typed keep their class; transport failures become
+// ServerUnreachableError and non-2xx responses become APIError (or
+// NotAuthenticatedError / NotFoundError for 401 and 404).
+func Wrap(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+
+	var cliErr CLIError
+	if errors.As(err, &cliErr) {
+		return cliErr
+	}
+
+	var ambiguous *anytypecli.AmbiguousNameError
+	if errors.As(err, &ambiguous) {
+		return fromAmbiguous(ambiguous)
+	}
+
+	var missing *anytypecli.MissingError
+	if errors.As(err, &missing) {
+		return &ValidationError{Message: fmt.Sprintf("%s: %s", msg, missing)}
+	}
+
+	if isUnreachable(err) {
+		return &ServerUnreachableError{Message: msg, Err: err}
+	}
+
+	if m := statusPattern.FindStringSubmatch(err.Error()); m != nil {
+		status, _ := strconv.Atoi(m[1])
+		body := strings.TrimSpace(m[2])
+		switch status {
+		case 401:
+			return &NotAuthenticatedError{
+				Message: fmt.Sprintf("%s: the API rejected the app key, run 'anytype-cli auth --force'", msg),
+			}
+		case 404:
+			return &NotFoundError{Message: fmt.Sprintf("%s: not found: %s", msg, body)}
+		}
+		return &APIError{Message: msg, Status: status, Body: body}
+	}
+
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// fromAmbiguous converts an ambiguous name lookup of the library into its CLI error
+func fromAmbiguous(err *anytypecli.AmbiguousNameError) *AmbiguousNameError {
+	cliErr := &AmbiguousNameError{Resource: err.Resource, Name: err.Name}
+	for _, match := range err.Matches {
+		cliErr.Matches = append(cliErr.Matches, fmt.Sprintf("'%s' (ID: %s)", match.Name, match.ID))
+	}
+	return cliErr
+}
+
+// isUnreachable reports whether err is a connection-level failure
+func isUnreachable(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.Timeout() {
+		return true
+	}
+	return false
+}
+
+// ExitCode returns the exit code for err
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var cliErr CLIError
+	if errors.As(err, &cliErr) {
+		return cliErr.ExitCode()
+	}
+	return ExitGeneric
+}
+
+// KindOf returns the error class of err
+func KindOf(err error) string {
+	var cliErr CLIError
+	if errors.As(err, &cliErr) {
+		return cliErr.Kind()
+	}
+	return KindGeneric
+}
@@ -0,0 +1,68 @@
+package clierrors
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+)
+
+func TestWrapClassifiesSDKErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantKind string
+		wantCode int
+	}{
+		{"not found", errors.New(`request failed with status 404: {"message":"object not found"}`), KindNotFound, ExitNotFound},
+		{"unauthorized", errors.New("request failed with status 401: unauthorized"), KindNotAuthenticated, ExitNotAuthenticated},
+		{"server error", errors.New("request failed with status 500: boom"), KindAPI, ExitAPI},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, KindServerUnreachable, ExitServerUnreachable},
+		{"typed error kept", &ValidationError{Message: "bad flag"}, KindValidation, ExitValidation},
+		{"differences kept", &DifferencesError{Message: "schemas differ"}, KindDifferences, ExitDifferences},
+		{"cancelled kept", &CancelledError{Message: "cancelled"}, KindCancelled, ExitCancelled},
+		{"partial failure kept", &PartialFailureError{Message: "1 of 2 objects could not be changed"}, KindPartialFailure, ExitPartialFailure},
+		{"ambiguous library lookup", fmt.Errorf("resolve: %w", &anytypecli.AmbiguousNameError{Resource: "space"}), KindAmbiguousName, ExitAmbiguousName},
+		{"missing in destination", fmt.Errorf("copy: %w", &anytypecli.MissingError{Resource: "type", Name: "ot-task"}), KindValidation, ExitValidation},
+		{"other", errors.New("boom"), KindGeneric, ExitGeneric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Wrap(tt.err, "failed to do it")
+			if got := KindOf(err); got != tt.wantKind {
+				t.Errorf("KindOf() = %q, want %q", got, tt.wantKind)
+			}
+			if got := ExitCode(err); got != tt.wantCode {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWrapKeepsStatus(t *testing.T) {
+	err := Wrap(errors.New("request failed with status 409: conflict"), "failed to create")
+	var apiErr *APIError
+	if !errors.As(fmt.Errorf("outer: %w", err), &apiErr) {
+		t.Fatalf("expected an APIError, got %T", err)
+	}
+	if apiErr.Status != 409 || apiErr.Body != "conflict" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+}
+
+func TestWrapNil(t *testing.T) {
+	if Wrap(nil, "nothing") != nil {
+		t.Error("Wrap(nil) should be nil")
+	}
+}
+
+func TestWrapMissingNamesNoFlag(t *testing.T) {
+	err := Wrap(&anytypecli.MissingError{Resource: "type", Name: "ot-task"}, "failed to copy object")
+	if strings.Contains(err.Error(), "--") {
+		t.Errorf("Wrap() = %q, want no command-specific flag", err)
+	}
+}
@@ -0,0 +1,43 @@
+package clierrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// jsonError is the shape of an error written with -o json
+type jsonError struct {
+	Error struct {
+		Kind     string `json:"kind"`
+		Message  string `json:"message"`
+		ExitCode int    `json:"exit_code"`
+		Status   int    `json:"status,omitempty"`
+	} `json:"error"`
+}
+
+// Print writes err to w, as a JSON object when asJSON is set and as a plain
+// "Error: ..." line otherwise.
+func Print(w io.Writer, err error, asJSON bool) {
+	if !asJSON {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return
+	}
+
+	var out jsonError
+	out.Error.Kind = KindOf(err)
+	out.Error.Message = err.Error()
+	out.Error.ExitCode = ExitCode(err)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		out.Error.Status = apiErr.Status
+	}
+
+	data, marshalErr := json.MarshalIndent(out, "", "  ")
+	if marshalErr != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return
+	}
+	fmt.Fprintln(w, string(data))
+}
@@ -11,6 +11,11 @@ import (
 type Config struct {
 	AppKey  string `mapstructure:"app_key"`
 	BaseURL string `mapstructure:"base_url"`
+
+	// Verbose logs every API call (method, path, status, latency) to stderr
+	Verbose bool `mapstructure:"verbose"`
+	// DebugHTTP dumps full request and response bodies to stderr
+	DebugHTTP bool `mapstructure:"debug_http"`
 }
 
 // DefaultBaseURL is the default Anytype local API URL
@@ -0,0 +1,92 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BoardColumn is a column of a board: a title and the cards below it
+type BoardColumn struct {
+	Title string
+	Cards []string
+}
+
+// Board renders columns side by side, as in a kanban view. Each column is as wide as its
+// title or longest card, up to maxWidth; longer cards are truncated.
+func Board(columns []BoardColumn, maxWidth int) string {
+	if len(columns) == 0 {
+		return ""
+	}
+
+	table := NewTable(make([]string, len(columns))).SetMaxWidth(maxWidth).SetTruncate(true)
+	rows := 0
+	for i, column := range columns {
+		table.Headers[i] = Truncate(column.Title, maxWidth)
+		rows = max(rows, len(column.Cards))
+	}
+	for r := 0; r < rows; r++ {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			if r < len(column.Cards) {
+				row[i] = column.Cards[r]
+			}
+		}
+		table.AddRow(row)
+	}
+	return table.String()
+}
+
+// Calendar renders the month of month as a grid of weeks starting on Monday, listing the
+// entries of each day, keyed by day of the month, below its number. Entries are truncated
+// to the width of a cell.
+func Calendar(month time.Time, entries map[int][]string, cellWidth int) string {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	days := first.AddDate(0, 1, -1).Day()
+	// Monday is the first column
+	offset := (int(first.Weekday()) + 6) % 7
+
+	var b strings.Builder
+	b.WriteString(first.Format("January 2006") + "\n")
+
+	cell := func(s string) string {
+		return fmt.Sprintf("%-*s", cellWidth, Truncate(s, cellWidth))
+	}
+	writeRow := func(cells []string) {
+		b.WriteString(strings.TrimRight(strings.Join(cells, "  "), " ") + "\n")
+	}
+
+	var header, separator []string
+	for _, name := range []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"} {
+		header = append(header, cell(name))
+		separator = append(separator, strings.Repeat("-", cellWidth))
+	}
+	writeRow(header)
+	writeRow(separator)
+
+	for weekStart := 1 - offset; weekStart <= days; weekStart += 7 {
+		numbers := make([]string, 7)
+		lines := 0
+		for i := range numbers {
+			day := weekStart + i
+			numbers[i] = cell("")
+			if day >= 1 && day <= days {
+				numbers[i] = fmt.Sprintf("%*d", cellWidth, day)
+				lines = max(lines, len(entries[day]))
+			}
+		}
+		writeRow(numbers)
+
+		for line := 0; line < lines; line++ {
+			cells := make([]string, 7)
+			for i := range cells {
+				cells[i] = cell("")
+				if day := weekStart + i; day >= 1 && day <= days && line < len(entries[day]) {
+					cells[i] = cell(entries[day][line])
+				}
+			}
+			writeRow(cells)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// progressWidth is the number of cells of a progress bar
+const progressWidth = 30
+
+// Progress draws a progress bar on a terminal, redrawing a single line. A nil Progress,
+// as returned for writers that are not terminals, draws nothing.
+type Progress struct {
+	w     io.Writer
+	total int
+	done  int
+}
+
+// NewProgress returns a progress bar counting up to total on w, or nil if w is not a terminal
+func NewProgress(w io.Writer, total int) *Progress {
+	if !IsTerminal(w) {
+		return nil
+	}
+	p := &Progress{w: w, total: total}
+	p.draw()
+	return p
+}
+
+// Increment counts one more item done and redraws the bar
+func (p *Progress) Increment() {
+	if p == nil {
+		return
+	}
+	p.done++
+	p.draw()
+}
+
+// Finish erases the bar
+func (p *Progress) Finish() {
+	if p == nil {
+		return
+	}
+	fmt.Fprintf(p.w, "\r%s\r", strings.Repeat(" ", progressWidth+24))
+}
+
+func (p *Progress) draw() {
+	filled := progressWidth
+	if p.total > 0 {
+		filled = p.done * progressWidth / p.total
+	}
+	fmt.Fprintf(p.w, "\r[%s%s] %d/%d", strings.Repeat("#", filled), strings.Repeat(" ", progressWidth-filled), p.done, p.total)
+}
+
+// IsTerminal reports whether w is a terminal
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
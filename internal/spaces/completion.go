@@ -0,0 +1,41 @@
+// Package spaces provides shell completion for space references
+package spaces
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/epheo/anytype-go"
+	"github.com/spf13/cobra"
+)
+
+// CompleteSpaces returns shell completions for space IDs and names
+func CompleteSpaces(ctx context.Context, anytypeClient anytype.Client) ([]string, cobra.ShellCompDirective) {
+	// Get all spaces
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := anytypeClient.Spaces().List(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	// Return both IDs and names for completion
+	var completions []string
+	for _, space := range resp.Data {
+		// Add space ID with description
+		completions = append(completions, space.ID+"\t"+space.Name)
+		// Add space name if it doesn't contain special characters
+		if !strings.ContainsAny(space.Name, " \t\n\r") {
+			completions = append(completions, space.Name+"\t"+space.ID)
+		} else {
+			// Add quoted name for spaces with special characters
+			quotedName := fmt.Sprintf("%q", space.Name)
+			completions = append(completions, quotedName+"\t"+space.ID)
+		}
+	}
+
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
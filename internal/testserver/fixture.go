@@ -0,0 +1,193 @@
+package testserver
+
+import "github.com/epheo/anytype-go"
+
+// Fixture identifiers, for use in tests
+const (
+	SpaceEngineering        = "space-eng"
+	SpaceEngineeringArchive = "space-eng-archive"
+	SpacePersonal           = "space-personal"
+
+	ObjectRoadmap    = "obj-roadmap"
+	ObjectWriteDocs  = "obj-write-docs"
+	ObjectFixBug     = "obj-fix-bug"
+	ObjectSprintList = "obj-sprint-board"
+	ObjectOldSpec    = "obj-old-spec"
+	ObjectDraft      = "obj-draft"
+
+	TemplateTaskDefault  = "tpl-task-default"
+	TemplateMeetingNotes = "tpl-meeting-notes"
+
+	ViewGrid   = "view-grid"
+	ViewKanban = "view-kanban"
+
+	PropertyStatus = "prop-status"
+
+	TagOpen       = "tag-open"
+	TagInProgress = "tag-progress"
+	TagDone       = "tag-done"
+
+	MemberAlice = "member-alice"
+	MemberBob   = "member-bob"
+	MemberCarol = "member-carol"
+)
+
+// seed fills the server with the default fixture: three spaces, of which
+// "Engineering" holds a page, two tasks, a collection, four properties and two members,
+// and "Engineering Archive" two archived pages.
+func (s *Server) seed() {
+	s.Spaces = []anytype.Space{
+		{
+			ID:           SpaceEngineering,
+			Name:         "Engineering",
+			Description:  "Team space",
+			Icon:         &anytype.Icon{Format: anytype.IconFormatEmoji, Emoji: "🛠"},
+			HomeID:       "obj-home",
+			ArchiveID:    "obj-archive",
+			ProfileID:    "obj-profile",
+			CreatedAt:    1760000000000,
+			LastOpenedAt: 1760600000000,
+		},
+		{
+			ID:          SpaceEngineeringArchive,
+			Name:        "Engineering Archive",
+			Description: "Old projects",
+			CreatedAt:   1700000000000,
+		},
+		{
+			ID:        SpacePersonal,
+			Name:      "Personal",
+			CreatedAt: 1650000000000,
+		},
+	}
+
+	page := &anytype.Type{Key: "ot-page", Name: "Page", Layout: "basic", RecommendedLayout: "basic",
+		PropertyDefinitions: []anytype.PropertyDefinition{
+			{Key: "description", Name: "Description", Format: "text"},
+		}}
+	task := &anytype.Type{Key: "ot-task", Name: "Task", Description: "A unit of work", Layout: "action", RecommendedLayout: "action",
+		PropertyDefinitions: []anytype.PropertyDefinition{
+			{Key: "status", Name: "Status", Format: "select"},
+			{Key: "due_date", Name: "Due date", Format: "date"},
+			{Key: "done", Name: "Done", Format: "checkbox"},
+		}}
+	collection := &anytype.Type{Key: "ot-collection", Name: "Collection", Layout: "collection", RecommendedLayout: "collection"}
+
+	s.Types = map[string][]*anytype.Type{
+		SpaceEngineering:        {page, task, collection},
+		SpaceEngineeringArchive: {{Key: "ot-page", Name: "Page", Layout: "basic", RecommendedLayout: "basic"}},
+		SpacePersonal:           {{Key: "ot-page", Name: "Page", Layout: "basic", RecommendedLayout: "basic"}},
+	}
+
+	s.Properties = map[string][]*Property{
+		SpaceEngineering: {
+			{Object: "property", ID: "prop-description", Key: "description", Name: "Description", Format: "text"},
+			{Object: "property", ID: PropertyStatus, Key: "status", Name: "Status", Format: "select"},
+			{Object: "property", ID: "prop-due-date", Key: "due_date", Name: "Due date", Format: "date"},
+			{Object: "property", ID: "prop-done", Key: "done", Name: "Done", Format: "checkbox"},
+		},
+	}
+
+	s.Tags = map[string]map[string][]*anytype.Tag{
+		SpaceEngineering: {
+			PropertyStatus: {
+				{Object: "tag", ID: TagOpen, Key: "open", Name: "Open", Color: "teal"},
+				{Object: "tag", ID: TagInProgress, Key: "in_progress", Name: "In Progress", Color: "yellow"},
+				{Object: "tag", ID: TagDone, Key: "done", Name: "Done", Color: "grey"},
+			},
+		},
+	}
+
+	s.Templates = map[string]map[string][]*anytype.Template{
+		SpaceEngineering: {
+			"ot-task": {
+				{ID: TemplateTaskDefault, Name: "Default Task", Icon: &anytype.Icon{Format: anytype.IconFormatEmoji, Emoji: "✅"}},
+			},
+		},
+	}
+
+	s.TemplateBodies = map[string]string{}
+	s.Profiles = map[string]*anytype.Object{}
+
+	s.Objects = map[string][]*anytype.Object{
+		SpaceEngineering: {
+			{
+				ID: ObjectRoadmap, Name: "Roadmap", SpaceID: SpaceEngineering, TypeKey: "ot-page", Layout: "basic",
+				Icon:     &anytype.Icon{Format: anytype.IconFormatEmoji, Emoji: "🗺"},
+				Type:     &anytype.Type{Key: "ot-page", Name: "Page"},
+				Markdown: "# Roadmap\n\n- Q1: CLI\n- Q2: Sync\n",
+				Properties: []anytype.Property{
+					{Key: "description", Name: "Description", Format: "text", Text: "Where we are going"},
+				},
+			},
+			{
+				ID: ObjectWriteDocs, Name: "Write docs", SpaceID: SpaceEngineering, TypeKey: "ot-task", Layout: "action",
+				Type:     &anytype.Type{Key: "ot-task", Name: "Task"},
+				Markdown: "Document every command.\n",
+				Properties: []anytype.Property{
+					{Key: "status", Name: "Status", Format: "select", Select: &anytype.Tag{ID: TagInProgress, Name: "In Progress", Color: "yellow"}},
+					{Key: "due_date", Name: "Due date", Format: "date", Date: "2026-11-01T00:00:00Z"},
+				},
+			},
+			{
+				ID: ObjectFixBug, Name: "Fix bug", SpaceID: SpaceEngineering, TypeKey: "ot-task", Layout: "action",
+				Type:     &anytype.Type{Key: "ot-task", Name: "Task"},
+				Markdown: "Crash on empty space.\n",
+				Properties: []anytype.Property{
+					{Key: "status", Name: "Status", Format: "select", Select: &anytype.Tag{ID: TagDone, Name: "Done", Color: "grey"}},
+				},
+			},
+			{
+				ID: ObjectSprintList, Name: "Sprint Board", SpaceID: SpaceEngineering, TypeKey: "ot-collection", Layout: "collection",
+				Type: &anytype.Type{Key: "ot-collection", Name: "Collection"},
+			},
+		},
+		SpaceEngineeringArchive: {
+			{
+				ID: ObjectOldSpec, Name: "Old spec", SpaceID: SpaceEngineeringArchive, TypeKey: "ot-page", Layout: "basic", Archived: true,
+				Type:     &anytype.Type{Key: "ot-page", Name: "Page"},
+				Markdown: "Superseded.\n",
+				Properties: []anytype.Property{
+					{Key: "last_modified_date", Name: "Last modified date", Format: "date", Date: "2026-08-01T10:00:00Z"},
+				},
+			},
+			{
+				ID: ObjectDraft, Name: "Draft", SpaceID: SpaceEngineeringArchive, TypeKey: "ot-page", Layout: "basic", Archived: true,
+				Type: &anytype.Type{Key: "ot-page", Name: "Page"},
+				Properties: []anytype.Property{
+					{Key: "last_modified_date", Name: "Last modified date", Format: "date", Date: "2026-10-10T10:00:00Z"},
+				},
+			},
+		},
+		SpacePersonal: {
+			{
+				ID: "obj-diary", Name: "Diary", SpaceID: SpacePersonal, TypeKey: "ot-page", Layout: "basic",
+				Type:     &anytype.Type{Key: "ot-page", Name: "Page"},
+				Markdown: "Dear diary\n",
+			},
+		},
+	}
+
+	s.Lists = map[string]map[string]*List{
+		SpaceEngineering: {
+			ObjectSprintList: {
+				Views: []anytype.ListView{
+					{ID: ViewGrid, Name: "All", Layout: "grid"},
+					{ID: ViewKanban, Name: "Board", Layout: "kanban"},
+				},
+				ObjectIDs: []string{ObjectWriteDocs, ObjectFixBug},
+			},
+		},
+	}
+
+	s.Members = map[string][]*anytype.Member{
+		SpaceEngineering: {
+			{ID: MemberAlice, Name: "Alice", GlobalName: "alice.any", Identity: "identity-alice", Role: "owner", Status: "active",
+				Icon: &anytype.Icon{Format: anytype.IconFormatEmoji, Emoji: "🦊"}},
+			{ID: MemberBob, Name: "Bob", GlobalName: "bob.any", Identity: "identity-bob", Role: "editor", Status: "active"},
+		},
+		SpacePersonal: {
+			{ID: MemberAlice, Name: "Alice", GlobalName: "alice.any", Identity: "identity-alice", Role: "owner", Status: "active"},
+		},
+	}
+}
@@ -0,0 +1,1315 @@
+// Package testserver provides an in-process fake of the local Anytype HTTP API.
+//
+// The fake keeps its state in memory and serves the endpoints used by the CLI under
+// the same /v1 paths as the real API. Responses are encoded from the anytype-go SDK
+// types so that they decode losslessly on the client side.
+package testserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/epheo/anytype-go"
+)
+
+// AppKey is the app key accepted by the fake server
+const AppKey = "test-app-key"
+
+// VerificationCode is the code that completes the fake auth challenge
+const VerificationCode = "1234"
+
+// List holds the views and members of a list (collection or set) object
+type List struct {
+	Views     []anytype.ListView
+	ObjectIDs []string
+	// SourceTypes are the type keys of a set, whose objects it shows instead of ObjectIDs
+	SourceTypes []string
+}
+
+// Property is a property definition, as served by the properties endpoints
+type Property struct {
+	Object string `json:"object"`
+	ID     string `json:"id"`
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Format string `json:"format"`
+}
+
+// Request records a request received by the server
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// Server is a fake Anytype API server
+type Server struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	nextID         int
+	Spaces         []anytype.Space
+	Objects        map[string][]*anytype.Object              // space ID -> objects
+	Types          map[string][]*anytype.Type                // space ID -> types
+	Templates      map[string]map[string][]*anytype.Template // space ID -> type key -> templates
+	TemplateBodies map[string]string                         // template ID -> markdown body
+	Lists          map[string]map[string]*List               // space ID -> list ID -> list
+	Members        map[string][]*anytype.Member              // space ID -> members
+	Properties     map[string][]*Property                    // space ID -> properties
+	Tags           map[string]map[string][]*anytype.Tag      // space ID -> property ID -> tags
+	Profiles       map[string]*anytype.Object                // space ID -> profile object of the current user
+	Requests       []Request
+}
+
+// New starts a fake server seeded with the default fixture. Callers must Close it.
+func New() *Server {
+	s := &Server{}
+	s.seed()
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// NewEmpty starts a fake server without any data
+func NewEmpty() *Server {
+	s := &Server{
+		Objects:        map[string][]*anytype.Object{},
+		Types:          map[string][]*anytype.Type{},
+		Templates:      map[string]map[string][]*anytype.Template{},
+		TemplateBodies: map[string]string{},
+		Lists:          map[string]map[string]*List{},
+		Members:        map[string][]*anytype.Member{},
+		Properties:     map[string][]*Property{},
+		Tags:           map[string]map[string][]*anytype.Tag{},
+		Profiles:       map[string]*anytype.Object{},
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// Lock acquires the server state lock, for tests that inspect or mutate fixtures
+func (s *Server) Lock() { s.mu.Lock() }
+
+// Unlock releases the server state lock
+func (s *Server) Unlock() { s.mu.Unlock() }
+
+// newID returns a fresh deterministic identifier with the given prefix
+func (s *Server) newID(prefix string) string {
+	s.nextID++
+	return fmt.Sprintf("%s-new-%d", prefix, s.nextID)
+}
+
+// routes registers all fake endpoints
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /v1/auth/challenges", s.createChallenge)
+	mux.HandleFunc("POST /v1/auth/api_keys", s.createAPIKey)
+
+	mux.HandleFunc("GET /v1/spaces", s.authed(s.listSpaces))
+	mux.HandleFunc("POST /v1/spaces", s.authed(s.createSpace))
+	mux.HandleFunc("GET /v1/spaces/{space}", s.authed(s.getSpace))
+	mux.HandleFunc("PATCH /v1/spaces/{space}", s.authed(s.updateSpace))
+
+	mux.HandleFunc("GET /v1/spaces/{space}/objects", s.authed(s.listObjects))
+	mux.HandleFunc("POST /v1/spaces/{space}/objects", s.authed(s.createObject))
+	mux.HandleFunc("GET /v1/spaces/{space}/objects/{object}", s.authed(s.getObject))
+	mux.HandleFunc("PATCH /v1/spaces/{space}/objects/{object}", s.authed(s.updateObject))
+	mux.HandleFunc("DELETE /v1/spaces/{space}/objects/{object}", s.authed(s.deleteObject))
+
+	mux.HandleFunc("GET /v1/spaces/{space}/types", s.authed(s.listTypes))
+	mux.HandleFunc("POST /v1/spaces/{space}/types", s.authed(s.createType))
+	mux.HandleFunc("GET /v1/spaces/{space}/types/{type}", s.authed(s.getType))
+	mux.HandleFunc("PATCH /v1/spaces/{space}/types/{type}", s.authed(s.updateType))
+	mux.HandleFunc("DELETE /v1/spaces/{space}/types/{type}", s.authed(s.deleteType))
+	mux.HandleFunc("GET /v1/spaces/{space}/types/{type}/templates", s.authed(s.listTemplates))
+	mux.HandleFunc("GET /v1/spaces/{space}/types/{type}/templates/{template}", s.authed(s.getTemplate))
+
+	mux.HandleFunc("GET /v1/spaces/{space}/properties", s.authed(s.listProperties))
+	mux.HandleFunc("POST /v1/spaces/{space}/properties", s.authed(s.createProperty))
+	mux.HandleFunc("GET /v1/spaces/{space}/properties/{property}", s.authed(s.getProperty))
+	mux.HandleFunc("PATCH /v1/spaces/{space}/properties/{property}", s.authed(s.updateProperty))
+	mux.HandleFunc("DELETE /v1/spaces/{space}/properties/{property}", s.authed(s.deleteProperty))
+	mux.HandleFunc("GET /v1/spaces/{space}/properties/{property}/tags", s.authed(s.listTags))
+	mux.HandleFunc("POST /v1/spaces/{space}/properties/{property}/tags", s.authed(s.createTag))
+	mux.HandleFunc("GET /v1/spaces/{space}/properties/{property}/tags/{tag}", s.authed(s.getTag))
+	mux.HandleFunc("PATCH /v1/spaces/{space}/properties/{property}/tags/{tag}", s.authed(s.updateTag))
+	mux.HandleFunc("DELETE /v1/spaces/{space}/properties/{property}/tags/{tag}", s.authed(s.deleteTag))
+
+	mux.HandleFunc("GET /v1/spaces/{space}/lists/{list}/views", s.authed(s.listViews))
+	mux.HandleFunc("GET /v1/spaces/{space}/lists/{list}/views/{view}/objects", s.authed(s.listViewObjects))
+	mux.HandleFunc("GET /v1/spaces/{space}/lists/{list}/objects", s.authed(s.listListObjects))
+	mux.HandleFunc("POST /v1/spaces/{space}/lists/{list}/objects", s.authed(s.addListObjects))
+	mux.HandleFunc("DELETE /v1/spaces/{space}/lists/{list}/objects/{object}", s.authed(s.removeListObject))
+
+	mux.HandleFunc("GET /v1/spaces/{space}/members", s.authed(s.listMembers))
+	mux.HandleFunc("GET /v1/spaces/{space}/members/{member}", s.authed(s.getMember))
+	mux.HandleFunc("PATCH /v1/spaces/{space}/members/{member}", s.authed(s.updateMember))
+
+	mux.HandleFunc("POST /v1/search", s.authed(s.searchGlobal))
+	mux.HandleFunc("POST /v1/spaces/{space}/search", s.authed(s.searchSpace))
+
+	return s.record(mux)
+}
+
+// record logs every request before dispatching it
+func (s *Server) record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		s.mu.Lock()
+		s.Requests = append(s.Requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: string(body)})
+		s.mu.Unlock()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authed rejects requests without the expected bearer token and holds the state lock
+func (s *Server) authed(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+AppKey {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		handler(w, r)
+	}
+}
+
+// paginate applies the limit and offset query parameters and returns the pagination block
+func paginate[T any](r *http.Request, items []T) ([]T, map[string]interface{}) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 100
+	}
+	total := len(items)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return items[offset:end], map[string]interface{}{
+		"total":    total,
+		"offset":   offset,
+		"limit":    limit,
+		"has_more": end < total,
+	}
+}
+
+// writeJSON encodes v as the response body
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error body in the shape used by the Anytype API
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"object":  "error",
+		"status":  status,
+		"code":    strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_")),
+		"message": message,
+	})
+}
+
+// decode reads a JSON request body into v
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// space returns the space addressed by the request, writing a 404 if missing
+func (s *Server) space(w http.ResponseWriter, r *http.Request) (*anytype.Space, bool) {
+	id := r.PathValue("space")
+	for i := range s.Spaces {
+		if s.Spaces[i].ID == id {
+			return &s.Spaces[i], true
+		}
+	}
+	writeError(w, http.StatusNotFound, "space not found")
+	return nil, false
+}
+
+// object returns an object of the addressed space by ID
+func (s *Server) object(spaceID, objectID string) *anytype.Object {
+	for _, obj := range s.Objects[spaceID] {
+		if obj.ID == objectID {
+			return obj
+		}
+	}
+	return nil
+}
+
+// typeByKey returns a type of the space by key
+func (s *Server) typeByKey(spaceID, key string) *anytype.Type {
+	for _, typ := range s.Types[spaceID] {
+		if typ.Key == key {
+			return typ
+		}
+	}
+	return nil
+}
+
+// template returns a template of the space by ID, with the key of its type
+func (s *Server) template(spaceID, templateID string) (*anytype.Template, string) {
+	for typeKey, templates := range s.Templates[spaceID] {
+		for _, tpl := range templates {
+			if tpl.ID == templateID {
+				return tpl, typeKey
+			}
+		}
+	}
+	return nil, ""
+}
+
+// templateObject returns the object view of a template, as served by the object endpoints
+func (s *Server) templateObject(spaceID string, tpl *anytype.Template) *anytype.Object {
+	return &anytype.Object{
+		ID:       tpl.ID,
+		Name:     tpl.Name,
+		SpaceID:  spaceID,
+		TypeKey:  "template",
+		Layout:   "basic",
+		Icon:     tpl.Icon,
+		Archived: tpl.Archived,
+		Markdown: s.TemplateBodies[tpl.ID],
+		Type:     &anytype.Type{Key: "template", Name: "Template"},
+	}
+}
+
+func (s *Server) createChallenge(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"challenge_id": "challenge-1"})
+}
+
+func (s *Server) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ChallengeID string `json:"challenge_id"`
+		Code        string `json:"code"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.ChallengeID != "challenge-1" || req.Code != VerificationCode {
+		writeError(w, http.StatusUnauthorized, "invalid verification code")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"api_key": AppKey})
+}
+
+func (s *Server) listSpaces(w http.ResponseWriter, r *http.Request) {
+	data, pagination := paginate(r, s.Spaces)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "pagination": pagination})
+}
+
+func (s *Server) createSpace(w http.ResponseWriter, r *http.Request) {
+	var req anytype.CreateSpaceRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+	space := anytype.Space{
+		ID:          s.newID("space"),
+		Name:        req.Name,
+		Description: req.Description,
+		Icon:        req.Icon,
+	}
+	s.Spaces = append(s.Spaces, space)
+	s.seedSpace(space.ID)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"space": space})
+}
+
+// seedSpace gives a new space the built-in types and properties Anytype creates in every
+// space, and the current user as its owner
+func (s *Server) seedSpace(spaceID string) {
+	for _, typ := range []anytype.Type{
+		{Key: "ot-page", Name: "Page", Layout: "basic"},
+		{Key: "ot-task", Name: "Task", Layout: "action"},
+		{Key: "ot-collection", Name: "Collection", Layout: "collection"},
+		{Key: "ot-set", Name: "Set", Layout: "set"},
+	} {
+		typ.RecommendedLayout = typ.Layout
+		typ.PropertyDefinitions = []anytype.PropertyDefinition{}
+		s.Types[spaceID] = append(s.Types[spaceID], &typ)
+	}
+	for _, property := range []Property{
+		{Key: "description", Name: "Description", Format: "text"},
+		{Key: "status", Name: "Status", Format: "select"},
+		{Key: "due_date", Name: "Due date", Format: "date"},
+		{Key: "done", Name: "Done", Format: "checkbox"},
+	} {
+		property.Object, property.ID = "property", s.newID("prop")
+		s.Properties[spaceID] = append(s.Properties[spaceID], &property)
+	}
+	s.Members[spaceID] = []*anytype.Member{
+		{ID: MemberAlice, Name: "Alice", GlobalName: "alice.any", Identity: "identity-alice", Role: "owner", Status: "active"},
+	}
+}
+
+func (s *Server) getSpace(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"space": space})
+}
+
+// updateSpaceRequest is the body of PATCH /spaces/{space}
+type updateSpaceRequest struct {
+	Name        *string       `json:"name"`
+	Description *string       `json:"description"`
+	Icon        *anytype.Icon `json:"icon"`
+}
+
+func (s *Server) updateSpace(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	var req updateSpaceRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name != nil {
+		if *req.Name == "" {
+			writeError(w, http.StatusBadRequest, "name must not be empty")
+			return
+		}
+		space.Name = *req.Name
+	}
+	if req.Description != nil {
+		space.Description = *req.Description
+	}
+	if req.Icon != nil {
+		space.Icon = req.Icon
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"space": space})
+}
+
+func (s *Server) listObjects(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	data, pagination := paginate(r, s.Objects[space.ID])
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "pagination": pagination})
+}
+
+func (s *Server) createObject(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	var req anytype.CreateObjectRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.TypeKey == "template" {
+		s.createTemplate(w, space.ID, req)
+		return
+	}
+	typ := s.typeByKey(space.ID, req.TypeKey)
+	if typ == nil {
+		writeError(w, http.StatusBadRequest, "unknown type key: "+req.TypeKey)
+		return
+	}
+	properties, err := s.propertyValues(space.ID, req.Properties)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	obj := &anytype.Object{
+		ID:         s.newID("obj"),
+		Name:       req.Name,
+		SpaceID:    space.ID,
+		TypeKey:    typ.Key,
+		Layout:     typ.RecommendedLayout,
+		Icon:       req.Icon,
+		Markdown:   req.Body,
+		Type:       &anytype.Type{Key: typ.Key, Name: typ.Name},
+		Properties: properties,
+	}
+	s.Objects[space.ID] = append(s.Objects[space.ID], obj)
+	if typ.RecommendedLayout == "collection" || typ.RecommendedLayout == "set" {
+		s.createList(space.ID, obj, req)
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"object": obj})
+}
+
+// propertyValues converts the property values of a create or update request into the
+// properties of an object. Select values are tag IDs of the property.
+func (s *Server) propertyValues(spaceID string, values []map[string]interface{}) ([]anytype.Property, error) {
+	var properties []anytype.Property
+	for _, value := range values {
+		key, _ := value["key"].(string)
+		if key == "set_of" || key == "target_object_type" {
+			continue
+		}
+		var definition *Property
+		for _, candidate := range s.Properties[spaceID] {
+			if candidate.Key == key {
+				definition = candidate
+			}
+		}
+		if definition == nil {
+			return nil, fmt.Errorf("unknown property key: %s", key)
+		}
+		property := anytype.Property{ID: definition.ID, Key: key, Name: definition.Name, Format: definition.Format}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		var v struct {
+			Select      string   `json:"select"`
+			MultiSelect []string `json:"multi_select"`
+		}
+		json.Unmarshal(raw, &v)
+		switch definition.Format {
+		case "select":
+			if v.Select == "" {
+				// Clears the property
+				break
+			}
+			tag := s.tagByID(spaceID, definition.ID, v.Select)
+			if tag == nil {
+				return nil, fmt.Errorf("unknown tag %q of property %s", v.Select, key)
+			}
+			property.Select = tag
+		case "multi_select":
+			for _, id := range v.MultiSelect {
+				tag := s.tagByID(spaceID, definition.ID, id)
+				if tag == nil {
+					return nil, fmt.Errorf("unknown tag %q of property %s", id, key)
+				}
+				property.MultiSelect = append(property.MultiSelect, *tag)
+			}
+		default:
+			// The other formats are named after their field of anytype.Property
+			if err := json.Unmarshal(raw, &property); err != nil {
+				return nil, err
+			}
+			property.ID, property.Name, property.Format = definition.ID, definition.Name, definition.Format
+		}
+		properties = append(properties, property)
+	}
+	return properties, nil
+}
+
+// tagByID returns a tag of a property by ID, or nil
+func (s *Server) tagByID(spaceID, propertyID, tagID string) *anytype.Tag {
+	for _, tag := range s.Tags[spaceID][propertyID] {
+		if tag.ID == tagID {
+			return tag
+		}
+	}
+	return nil
+}
+
+// createList backs a new collection or set object with a list holding one grid view. The
+// source types of a set are also given by its set_of property.
+func (s *Server) createList(spaceID string, obj *anytype.Object, req anytype.CreateObjectRequest) {
+	list := &List{Views: []anytype.ListView{{ID: s.newID("view"), Name: "All", Layout: "grid"}}}
+	for _, property := range req.Properties {
+		if objects, ok := property["objects"].([]interface{}); ok && property["key"] == "set_of" {
+			for _, typeKey := range objects {
+				if key, ok := typeKey.(string); ok {
+					list.SourceTypes = append(list.SourceTypes, key)
+				}
+			}
+			obj.Properties = append(obj.Properties, anytype.Property{Key: "set_of", Name: "Set of", Format: "objects", Objects: list.SourceTypes})
+		}
+	}
+	if s.Lists[spaceID] == nil {
+		s.Lists[spaceID] = map[string]*List{}
+	}
+	s.Lists[spaceID][obj.ID] = list
+}
+
+func (s *Server) getObject(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	obj := s.object(space.ID, r.PathValue("object"))
+	if obj == nil {
+		if tpl, _ := s.template(space.ID, r.PathValue("object")); tpl != nil {
+			obj = s.templateObject(space.ID, tpl)
+		}
+	}
+	if profile := s.Profiles[space.ID]; obj == nil && profile != nil && profile.ID == r.PathValue("object") {
+		obj = profile
+	}
+	if obj == nil {
+		writeError(w, http.StatusNotFound, "object not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"object": obj})
+}
+
+// createTemplate creates a template for the type given by its target_object_type property
+func (s *Server) createTemplate(w http.ResponseWriter, spaceID string, req anytype.CreateObjectRequest) {
+	var typeKey string
+	for _, property := range req.Properties {
+		if property["key"] == "target_object_type" {
+			if objects, ok := property["objects"].([]interface{}); ok && len(objects) == 1 {
+				typeKey, _ = objects[0].(string)
+			}
+		}
+	}
+	if s.typeByKey(spaceID, typeKey) == nil {
+		writeError(w, http.StatusBadRequest, "unknown target type: "+typeKey)
+		return
+	}
+	tpl := &anytype.Template{ID: s.newID("tpl"), Name: req.Name, Icon: req.Icon}
+	if s.Templates[spaceID] == nil {
+		s.Templates[spaceID] = map[string][]*anytype.Template{}
+	}
+	s.Templates[spaceID][typeKey] = append(s.Templates[spaceID][typeKey], tpl)
+	s.TemplateBodies[tpl.ID] = req.Body
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"object": s.templateObject(spaceID, tpl)})
+}
+
+func (s *Server) updateObject(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		Name       *string                  `json:"name"`
+		Icon       *anytype.Icon            `json:"icon"`
+		Markdown   *string                  `json:"markdown"`
+		TypeKey    *string                  `json:"type_key"`
+		Properties []map[string]interface{} `json:"properties"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if obj := s.object(space.ID, r.PathValue("object")); obj != nil {
+		if req.TypeKey != nil {
+			i := slices.IndexFunc(s.Types[space.ID], func(t *anytype.Type) bool { return t.Key == *req.TypeKey })
+			if i < 0 {
+				writeError(w, http.StatusBadRequest, "unknown type key: "+*req.TypeKey)
+				return
+			}
+			typ := s.Types[space.ID][i]
+			obj.TypeKey, obj.Layout, obj.Type = typ.Key, typ.RecommendedLayout, &anytype.Type{Key: typ.Key, Name: typ.Name}
+		}
+		properties, err := s.propertyValues(space.ID, req.Properties)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for _, property := range properties {
+			i := slices.IndexFunc(obj.Properties, func(p anytype.Property) bool { return p.Key == property.Key })
+			if i < 0 {
+				obj.Properties = append(obj.Properties, property)
+			} else {
+				obj.Properties[i] = property
+			}
+		}
+		if req.Name != nil {
+			obj.Name = *req.Name
+		}
+		if req.Icon != nil {
+			obj.Icon = req.Icon
+		}
+		if req.Markdown != nil {
+			obj.Markdown = *req.Markdown
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"object": obj})
+		return
+	}
+	if tpl, _ := s.template(space.ID, r.PathValue("object")); tpl != nil {
+		if req.Name != nil {
+			tpl.Name = *req.Name
+		}
+		if req.Icon != nil {
+			tpl.Icon = req.Icon
+		}
+		if req.Markdown != nil {
+			s.TemplateBodies[tpl.ID] = *req.Markdown
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"object": s.templateObject(space.ID, tpl)})
+		return
+	}
+	writeError(w, http.StatusNotFound, "object not found")
+}
+
+func (s *Server) deleteObject(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	obj := s.object(space.ID, r.PathValue("object"))
+	if obj == nil {
+		if tpl, _ := s.template(space.ID, r.PathValue("object")); tpl != nil {
+			tpl.Archived = true
+			obj = s.templateObject(space.ID, tpl)
+		}
+	}
+	if obj == nil {
+		writeError(w, http.StatusNotFound, "object not found")
+		return
+	}
+	obj.Archived = true
+	writeJSON(w, http.StatusOK, map[string]interface{}{"object": obj})
+}
+
+func (s *Server) listTypes(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	types := []*anytype.Type{}
+	for _, typ := range s.Types[space.ID] {
+		if !typ.IsArchived {
+			types = append(types, typ)
+		}
+	}
+	data, pagination := paginate(r, types)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "pagination": pagination})
+}
+
+func (s *Server) createType(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	var req anytype.CreateTypeRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.Layout == "" {
+		writeError(w, http.StatusBadRequest, "name and layout are required")
+		return
+	}
+	if req.Key == "" {
+		req.Key = s.newID("type")
+	}
+	if s.typeByKey(space.ID, req.Key) != nil {
+		writeError(w, http.StatusConflict, "type key already exists: "+req.Key)
+		return
+	}
+	typ := &anytype.Type{
+		Key:                 req.Key,
+		Name:                req.Name,
+		Icon:                req.Icon,
+		Layout:              req.Layout,
+		RecommendedLayout:   req.Layout,
+		PropertyDefinitions: req.Properties,
+	}
+	if typ.PropertyDefinitions == nil {
+		typ.PropertyDefinitions = []anytype.PropertyDefinition{}
+	}
+	// As the API does, properties of the type that the space lacks are created
+	for _, def := range typ.PropertyDefinitions {
+		if !slices.ContainsFunc(s.Properties[space.ID], func(p *Property) bool { return p.Key == def.Key }) {
+			s.Properties[space.ID] = append(s.Properties[space.ID],
+				&Property{Object: "property", ID: s.newID("prop"), Key: def.Key, Name: def.Name, Format: def.Format})
+		}
+	}
+	s.Types[space.ID] = append(s.Types[space.ID], typ)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"type": typ})
+}
+
+// updateTypeRequest is the PATCH body of a type, absent fields are left unchanged
+type updateTypeRequest struct {
+	Key        *string                       `json:"key"`
+	Name       *string                       `json:"name"`
+	Layout     *string                       `json:"layout"`
+	Icon       *anytype.Icon                 `json:"icon"`
+	Properties *[]anytype.PropertyDefinition `json:"properties"`
+}
+
+func (s *Server) updateType(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	typ := s.typeByKey(space.ID, r.PathValue("type"))
+	if typ == nil {
+		writeError(w, http.StatusNotFound, "type not found")
+		return
+	}
+	var req updateTypeRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Key != nil {
+		typ.Key = *req.Key
+	}
+	if req.Name != nil {
+		typ.Name = *req.Name
+	}
+	if req.Layout != nil {
+		typ.Layout = *req.Layout
+		typ.RecommendedLayout = *req.Layout
+	}
+	if req.Icon != nil {
+		typ.Icon = req.Icon
+	}
+	if req.Properties != nil {
+		typ.PropertyDefinitions = *req.Properties
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"type": typ})
+}
+
+func (s *Server) deleteType(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	typ := s.typeByKey(space.ID, r.PathValue("type"))
+	if typ == nil {
+		writeError(w, http.StatusNotFound, "type not found")
+		return
+	}
+	typ.IsArchived = true
+	writeJSON(w, http.StatusOK, map[string]interface{}{"type": typ})
+}
+
+func (s *Server) getType(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	typ := s.typeByKey(space.ID, r.PathValue("type"))
+	if typ == nil {
+		writeError(w, http.StatusNotFound, "type not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"type": typ})
+}
+
+func (s *Server) listTemplates(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	if s.typeByKey(space.ID, r.PathValue("type")) == nil {
+		writeError(w, http.StatusNotFound, "type not found")
+		return
+	}
+	templates := []*anytype.Template{}
+	for _, tpl := range s.Templates[space.ID][r.PathValue("type")] {
+		if !tpl.Archived {
+			templates = append(templates, tpl)
+		}
+	}
+	data, pagination := paginate(r, templates)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "pagination": pagination})
+}
+
+func (s *Server) getTemplate(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	for _, tpl := range s.Templates[space.ID][r.PathValue("type")] {
+		if tpl.ID == r.PathValue("template") {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"template": tpl})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "template not found")
+}
+
+// property returns the addressed property, writing a 404 if missing
+func (s *Server) property(w http.ResponseWriter, r *http.Request) (string, *Property, bool) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return "", nil, false
+	}
+	for _, property := range s.Properties[space.ID] {
+		if property.ID == r.PathValue("property") {
+			return space.ID, property, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "property not found")
+	return "", nil, false
+}
+
+func (s *Server) listProperties(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	data, pagination := paginate(r, s.Properties[space.ID])
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "pagination": pagination})
+}
+
+// tagRequest is the body of a tag creation or update
+type tagRequest struct {
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func (s *Server) createProperty(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		Key    string       `json:"key"`
+		Name   string       `json:"name"`
+		Format string       `json:"format"`
+		Tags   []tagRequest `json:"tags"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.Format == "" {
+		writeError(w, http.StatusBadRequest, "name and format are required")
+		return
+	}
+	property := &Property{Object: "property", ID: s.newID("prop"), Key: req.Key, Name: req.Name, Format: req.Format}
+	if property.Key == "" {
+		property.Key = property.ID
+	}
+	for _, existing := range s.Properties[space.ID] {
+		if existing.Key == property.Key {
+			writeError(w, http.StatusConflict, "property key already exists: "+property.Key)
+			return
+		}
+	}
+	s.Properties[space.ID] = append(s.Properties[space.ID], property)
+	for _, tag := range req.Tags {
+		s.addTag(space.ID, property.ID, tag)
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"property": property})
+}
+
+func (s *Server) getProperty(w http.ResponseWriter, r *http.Request) {
+	_, property, ok := s.property(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"property": property})
+}
+
+func (s *Server) updateProperty(w http.ResponseWriter, r *http.Request) {
+	_, property, ok := s.property(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		Key  *string `json:"key"`
+		Name *string `json:"name"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Key != nil {
+		property.Key = *req.Key
+	}
+	if req.Name != nil {
+		property.Name = *req.Name
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"property": property})
+}
+
+func (s *Server) deleteProperty(w http.ResponseWriter, r *http.Request) {
+	spaceID, property, ok := s.property(w, r)
+	if !ok {
+		return
+	}
+	properties := s.Properties[spaceID]
+	for i := range properties {
+		if properties[i] == property {
+			s.Properties[spaceID] = append(properties[:i], properties[i+1:]...)
+			break
+		}
+	}
+	delete(s.Tags[spaceID], property.ID)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"property": property})
+}
+
+// addTag creates a tag of a property
+func (s *Server) addTag(spaceID, propertyID string, req tagRequest) *anytype.Tag {
+	tag := &anytype.Tag{Object: "tag", ID: s.newID("tag"), Key: req.Key, Name: req.Name, Color: req.Color}
+	if tag.Key == "" {
+		tag.Key = tag.ID
+	}
+	if s.Tags[spaceID] == nil {
+		s.Tags[spaceID] = map[string][]*anytype.Tag{}
+	}
+	s.Tags[spaceID][propertyID] = append(s.Tags[spaceID][propertyID], tag)
+	return tag
+}
+
+// tag returns the addressed tag of the addressed property, writing a 404 if missing
+func (s *Server) tag(w http.ResponseWriter, r *http.Request) (string, *Property, *anytype.Tag, bool) {
+	spaceID, property, ok := s.property(w, r)
+	if !ok {
+		return "", nil, nil, false
+	}
+	for _, tag := range s.Tags[spaceID][property.ID] {
+		if tag.ID == r.PathValue("tag") {
+			return spaceID, property, tag, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "tag not found")
+	return "", nil, nil, false
+}
+
+func (s *Server) listTags(w http.ResponseWriter, r *http.Request) {
+	spaceID, property, ok := s.property(w, r)
+	if !ok {
+		return
+	}
+	data, pagination := paginate(r, s.Tags[spaceID][property.ID])
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "pagination": pagination})
+}
+
+func (s *Server) createTag(w http.ResponseWriter, r *http.Request) {
+	spaceID, property, ok := s.property(w, r)
+	if !ok {
+		return
+	}
+	if property.Format != "select" && property.Format != "multi_select" {
+		writeError(w, http.StatusBadRequest, "property does not support tags")
+		return
+	}
+	var req tagRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name == "" || req.Color == "" {
+		writeError(w, http.StatusBadRequest, "name and color are required")
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"tag": s.addTag(spaceID, property.ID, req)})
+}
+
+func (s *Server) getTag(w http.ResponseWriter, r *http.Request) {
+	_, _, tag, ok := s.tag(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"tag": tag})
+}
+
+func (s *Server) updateTag(w http.ResponseWriter, r *http.Request) {
+	_, _, tag, ok := s.tag(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		Name  *string `json:"name"`
+		Color *string `json:"color"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Name != nil {
+		tag.Name = *req.Name
+	}
+	if req.Color != nil {
+		tag.Color = *req.Color
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"tag": tag})
+}
+
+func (s *Server) deleteTag(w http.ResponseWriter, r *http.Request) {
+	spaceID, property, tag, ok := s.tag(w, r)
+	if !ok {
+		return
+	}
+	tags := s.Tags[spaceID][property.ID]
+	for i := range tags {
+		if tags[i] == tag {
+			s.Tags[spaceID][property.ID] = append(tags[:i], tags[i+1:]...)
+			break
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"tag": tag})
+}
+
+// list returns the addressed list, writing a 404 if missing
+func (s *Server) list(w http.ResponseWriter, r *http.Request) (string, *List, bool) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return "", nil, false
+	}
+	list := s.Lists[space.ID][r.PathValue("list")]
+	if list == nil && space.ArchiveID != "" && r.PathValue("list") == space.ArchiveID {
+		list = s.archive(space.ID)
+	}
+	if list == nil {
+		writeError(w, http.StatusNotFound, "list not found")
+		return "", nil, false
+	}
+	return space.ID, list, true
+}
+
+// archive returns a list of the archived objects of a space, as its archive object holds
+func (s *Server) archive(spaceID string) *List {
+	list := &List{ObjectIDs: []string{}}
+	for _, obj := range s.Objects[spaceID] {
+		if obj.Archived {
+			list.ObjectIDs = append(list.ObjectIDs, obj.ID)
+		}
+	}
+	return list
+}
+
+// listObjectsData resolves the member IDs of a list into objects
+func (s *Server) listObjectsData(spaceID string, list *List) []*anytype.Object {
+	if list.SourceTypes != nil {
+		objects := []*anytype.Object{}
+		for _, obj := range s.Objects[spaceID] {
+			if slices.Contains(list.SourceTypes, obj.TypeKey) && !obj.Archived {
+				objects = append(objects, obj)
+			}
+		}
+		return objects
+	}
+	objects := make([]*anytype.Object, 0, len(list.ObjectIDs))
+	for _, id := range list.ObjectIDs {
+		if obj := s.object(spaceID, id); obj != nil {
+			objects = append(objects, obj)
+		}
+	}
+	return objects
+}
+
+func (s *Server) listViews(w http.ResponseWriter, r *http.Request) {
+	_, list, ok := s.list(w, r)
+	if !ok {
+		return
+	}
+	data, pagination := paginate(r, list.Views)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "pagination": pagination})
+}
+
+func (s *Server) listViewObjects(w http.ResponseWriter, r *http.Request) {
+	spaceID, list, ok := s.list(w, r)
+	if !ok {
+		return
+	}
+	found := false
+	for _, view := range list.Views {
+		if view.ID == r.PathValue("view") {
+			found = true
+		}
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, "view not found")
+		return
+	}
+	data, pagination := paginate(r, s.listObjectsData(spaceID, list))
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "pagination": pagination})
+}
+
+func (s *Server) listListObjects(w http.ResponseWriter, r *http.Request) {
+	spaceID, list, ok := s.list(w, r)
+	if !ok {
+		return
+	}
+	data, pagination := paginate(r, s.listObjectsData(spaceID, list))
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "pagination": pagination})
+}
+
+func (s *Server) addListObjects(w http.ResponseWriter, r *http.Request) {
+	spaceID, list, ok := s.list(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		Objects []string `json:"objects"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	for _, id := range req.Objects {
+		if s.object(spaceID, id) == nil {
+			writeError(w, http.StatusNotFound, "object not found: "+id)
+			return
+		}
+	}
+	for _, id := range req.Objects {
+		if !contains(list.ObjectIDs, id) {
+			list.ObjectIDs = append(list.ObjectIDs, id)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"message": "objects added"})
+}
+
+func (s *Server) removeListObject(w http.ResponseWriter, r *http.Request) {
+	_, list, ok := s.list(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("object")
+	for i, existing := range list.ObjectIDs {
+		if existing == id {
+			list.ObjectIDs = append(list.ObjectIDs[:i], list.ObjectIDs[i+1:]...)
+			writeJSON(w, http.StatusOK, map[string]string{"message": "object removed"})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "object not in list")
+}
+
+func (s *Server) listMembers(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	data, pagination := paginate(r, s.Members[space.ID])
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "pagination": pagination})
+}
+
+func (s *Server) getMember(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	for _, member := range s.Members[space.ID] {
+		if member.ID == r.PathValue("member") {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"member": member})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "member not found")
+}
+
+// updateMemberRequest is the body of PATCH /spaces/{space}/members/{member}
+type updateMemberRequest struct {
+	Status string `json:"status"`
+	Role   string `json:"role"`
+}
+
+// updateMember approves, declines or removes a member, or changes its role. Joining
+// members may only be approved or declined.
+func (s *Server) updateMember(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	var req updateMemberRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	for _, member := range s.Members[space.ID] {
+		if member.ID != r.PathValue("member") {
+			continue
+		}
+		switch req.Status {
+		case "", "active", "declined", "removed":
+		default:
+			writeError(w, http.StatusBadRequest, "invalid status")
+			return
+		}
+		switch req.Role {
+		case "", "viewer", "editor", "owner":
+		default:
+			writeError(w, http.StatusBadRequest, "invalid role")
+			return
+		}
+		joining := member.Status == "joining"
+		if joining != (req.Status == "active" || req.Status == "declined") && req.Status != "" {
+			writeError(w, http.StatusBadRequest, "member status is "+member.Status)
+			return
+		}
+		if req.Status != "" {
+			member.Status = req.Status
+		}
+		if req.Role != "" {
+			member.Role = req.Role
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"member": member})
+		return
+	}
+	writeError(w, http.StatusNotFound, "member not found")
+}
+
+func (s *Server) searchGlobal(w http.ResponseWriter, r *http.Request) {
+	var req anytype.SearchRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	var results []*anytype.Object
+	for _, space := range s.Spaces {
+		results = append(results, search(s.Objects[space.ID], req)...)
+	}
+	sortResults(results, req.Sort)
+	data, pagination := paginate(r, results)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "pagination": pagination})
+}
+
+func (s *Server) searchSpace(w http.ResponseWriter, r *http.Request) {
+	space, ok := s.space(w, r)
+	if !ok {
+		return
+	}
+	var req anytype.SearchRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	results := search(s.Objects[space.ID], req)
+	sortResults(results, req.Sort)
+	data, pagination := paginate(r, results)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "pagination": pagination})
+}
+
+// search matches non-archived objects by name and type key
+func search(objects []*anytype.Object, req anytype.SearchRequest) []*anytype.Object {
+	query := strings.ToLower(req.Query)
+	var results []*anytype.Object
+	for _, obj := range objects {
+		if obj.Archived {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(obj.Name), query) {
+			continue
+		}
+		if len(req.Types) > 0 && !contains(req.Types, obj.TypeKey) {
+			continue
+		}
+		results = append(results, obj)
+	}
+	return results
+}
+
+// sortResults orders search results; only sorting by name is meaningful in the fake
+func sortResults(results []*anytype.Object, sortOpts *anytype.SortOptions) {
+	if sortOpts == nil || sortOpts.Property != anytype.SortPropertyName {
+		return
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if sortOpts.Direction == anytype.SortDirectionDesc {
+			return results[i].Name > results[j].Name
+		}
+		return results[i].Name < results[j].Name
+	})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
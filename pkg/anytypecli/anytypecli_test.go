@@ -0,0 +1,669 @@
+package anytypecli_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/epheo/anytype-cli/internal/testserver"
+	"github.com/epheo/anytype-cli/pkg/anytypecli"
+	"github.com/epheo/anytype-go"
+	_ "github.com/epheo/anytype-go/client" // Register client implementation
+)
+
+// newClient returns an SDK client and an API talking to the fake server
+func newClient(srv *testserver.Server) (anytype.Client, *anytypecli.API) {
+	c := anytype.NewClient(anytype.WithBaseURL(srv.URL), anytype.WithAppKey(testserver.AppKey))
+	return c, anytypecli.NewAPI(srv.URL, testserver.AppKey)
+}
+
+func TestResolveSpace(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+	c, _ := newClient(srv)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{testserver.SpacePersonal, testserver.SpacePersonal},
+		{"engineering", testserver.SpaceEngineering},
+		{"Archive", testserver.SpaceEngineeringArchive},
+		{"unknown-id", "unknown-id"},
+	}
+	for _, tt := range tests {
+		got, err := anytypecli.ResolveSpace(context.Background(), c, tt.input)
+		if err != nil {
+			t.Errorf("ResolveSpace(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveSpace(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	_, err := anytypecli.ResolveSpace(context.Background(), c, "Eng")
+	var ambiguous *anytypecli.AmbiguousNameError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Matches) != 2 {
+		t.Errorf("ResolveSpace(Eng) error = %v, want an AmbiguousNameError with 2 matches", err)
+	}
+}
+
+func TestPaginateFollowsPages(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+	_, api := newClient(srv)
+
+	path := "/spaces/" + testserver.SpaceEngineering + "/objects"
+	objects, err := anytypecli.Collect(anytypecli.Paginate[anytype.Object](context.Background(), api, path, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 4 {
+		t.Fatalf("got %d objects, want 4", len(objects))
+	}
+
+	var queries []string
+	for _, req := range srv.Requests {
+		if req.Path == "/v1"+path {
+			queries = append(queries, req.Query)
+		}
+	}
+	want := []string{"limit=3&offset=0", "limit=3&offset=3"}
+	if strings.Join(queries, " ") != strings.Join(want, " ") {
+		t.Errorf("queries = %v, want %v", queries, want)
+	}
+}
+
+func TestPaginateStopsAtError(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+	_, api := newClient(srv)
+	api.AppKey = "wrong"
+
+	_, err := anytypecli.AllObjects(context.Background(), api, testserver.SpaceEngineering)
+	var statusErr *anytypecli.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Status != 401 {
+		t.Errorf("error = %v, want a 401 StatusError", err)
+	}
+}
+
+func TestDeleteObjectsReportsEachObject(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+	c, _ := newClient(srv)
+
+	results := anytypecli.DeleteObjects(context.Background(), c, testserver.SpaceEngineering,
+		[]string{testserver.ObjectRoadmap, "obj-missing", testserver.ObjectFixBug})
+
+	if got := results.Succeeded(); strings.Join(got, ",") != testserver.ObjectRoadmap+","+testserver.ObjectFixBug {
+		t.Errorf("succeeded = %v", got)
+	}
+	if failed := results.Failed(); len(failed) != 1 || failed[0].ID != "obj-missing" {
+		t.Errorf("failed = %v", failed)
+	}
+	if err := results.Err(); err == nil || !strings.Contains(err.Error(), "obj-missing") {
+		t.Errorf("Err() = %v", err)
+	}
+}
+
+func TestAddToListBatches(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+	c, _ := newClient(srv)
+
+	ids := []string{testserver.ObjectRoadmap, testserver.ObjectSprintList, testserver.ObjectRoadmap}
+	results := anytypecli.AddToList(context.Background(), c, testserver.SpaceEngineering, testserver.ObjectSprintList, ids, 2)
+	if err := results.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	posts := 0
+	for _, req := range srv.Requests {
+		if req.Method == "POST" {
+			posts++
+		}
+	}
+	if posts != 2 {
+		t.Errorf("got %d add requests, want 2 batches", posts)
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	tests := []struct {
+		markdown string
+		want     anytypecli.MarkdownDocument
+	}{
+		{"# Title\n\nBody text\n", anytypecli.MarkdownDocument{Name: "Title", Body: "Body text\n"}},
+		{"\n\n# Spaced  \r\nBody", anytypecli.MarkdownDocument{Name: "Spaced", Body: "Body"}},
+		{"## Not a title\nBody", anytypecli.MarkdownDocument{Body: "## Not a title\nBody"}},
+		{"Plain text", anytypecli.MarkdownDocument{Body: "Plain text"}},
+	}
+	for _, tt := range tests {
+		if got := anytypecli.ParseMarkdown(tt.markdown); got != tt.want {
+			t.Errorf("ParseMarkdown(%q) = %+v, want %+v", tt.markdown, got, tt.want)
+		}
+	}
+}
+
+func TestImportExportMarkdown(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+	c, _ := newClient(srv)
+	ctx := context.Background()
+
+	obj, err := anytypecli.ImportMarkdown(ctx, c, testserver.SpaceEngineering, "# Meeting notes\n\n- decide\n", anytypecli.ImportOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.Name != "Meeting notes" || obj.TypeKey != anytypecli.DefaultTypeKey {
+		t.Errorf("imported object = %+v", obj)
+	}
+
+	markdown, err := anytypecli.ExportMarkdown(ctx, c, testserver.SpaceEngineering, obj.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if markdown != "- decide\n" {
+		t.Errorf("exported markdown = %q", markdown)
+	}
+
+	if _, err := anytypecli.ImportMarkdown(ctx, c, testserver.SpaceEngineering, "no title", anytypecli.ImportOptions{}); !errors.Is(err, anytypecli.ErrNoName) {
+		t.Errorf("import without a name: error = %v, want ErrNoName", err)
+	}
+}
+
+func TestParsePropertyDefinition(t *testing.T) {
+	def, err := anytypecli.ParsePropertyDefinition("due:date:Due date")
+	if err != nil || def != (anytype.PropertyDefinition{Key: "due", Format: "date", Name: "Due date"}) {
+		t.Errorf("ParsePropertyDefinition(due:date:Due date) = %+v, %v", def, err)
+	}
+	for _, invalid := range []string{"due", ":date", "due:datetime"} {
+		if _, err := anytypecli.ParsePropertyDefinition(invalid); err == nil {
+			t.Errorf("ParsePropertyDefinition(%q) succeeded", invalid)
+		}
+	}
+}
+
+func TestMergePropertyDefinitions(t *testing.T) {
+	defs := []anytype.PropertyDefinition{{Key: "a", Format: "text"}, {Key: "b", Format: "text"}, {Key: "c", Format: "text"}}
+	add := []anytype.PropertyDefinition{{Key: "b", Format: "number"}, {Key: "d", Format: "date"}}
+
+	merged, err := anytypecli.MergePropertyDefinitions(defs, add, []string{"c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, def := range merged {
+		got = append(got, def.Key+":"+def.Format)
+	}
+	if want := "a:text b:number d:date"; strings.Join(got, " ") != want {
+		t.Errorf("merged = %v, want %s", got, want)
+	}
+
+	if _, err := anytypecli.MergePropertyDefinitions(defs, nil, []string{"z"}); err == nil {
+		t.Error("removing an unknown property succeeded")
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	for input, want := range map[string]anytypecli.CreateTagRequest{
+		"Open":      {Name: "Open", Color: anytypecli.DefaultTagColor},
+		"Done:lime": {Name: "Done", Color: "lime"},
+	} {
+		if got, err := anytypecli.ParseTag(input); err != nil || got != want {
+			t.Errorf("ParseTag(%q) = %+v, %v, want %+v", input, got, err, want)
+		}
+	}
+	for _, invalid := range []string{"", ":red", "Open:green"} {
+		if _, err := anytypecli.ParseTag(invalid); err == nil {
+			t.Errorf("ParseTag(%q) succeeded", invalid)
+		}
+	}
+}
+
+func TestReadSchema(t *testing.T) {
+	schema, err := anytypecli.ReadSchema(strings.NewReader(`
+version: 1
+properties:
+  - {key: severity, name: Severity, format: select, tags: [{name: High, color: red}]}
+types:
+  - {key: incident, name: Incident, layout: action}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schema.Properties) != 1 || schema.Properties[0].Tags[0] != (anytypecli.SchemaTag{Name: "High", Color: "red"}) {
+		t.Errorf("properties = %+v", schema.Properties)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"version: 2",
+		"version: 1\nproperties: [{key: a, name: A, format: bigint}]",
+		"version: 1\ntypes: [{key: a, name: A, layout: basic}, {key: a, name: B, layout: basic}]",
+		"version: 1\ntypes: [{key: a, name: A, layout: basic, templates: [{body: text}]}]",
+		"version: 1\ntypes: [{key: a, name: A, layout: basic, templates: [{name: T}, {name: T}]}]",
+	} {
+		if _, err := anytypecli.ReadSchema(strings.NewReader(invalid)); err == nil {
+			t.Errorf("ReadSchema(%q) succeeded", invalid)
+		}
+	}
+}
+
+func TestDiffSchemas(t *testing.T) {
+	from := &anytypecli.Schema{Version: 1,
+		Properties: []anytypecli.SchemaProperty{{Key: "status", Format: "select", Tags: []anytypecli.SchemaTag{{Name: "Open"}, {Name: "Done"}}}},
+		Types:      []anytypecli.SchemaType{{Key: "a/b", Properties: []anytype.PropertyDefinition{{Key: "status", Format: "select"}}}},
+	}
+	to := &anytypecli.Schema{Version: 1,
+		Properties: []anytypecli.SchemaProperty{{Key: "status", Name: "Renamed", Format: "select", Tags: []anytypecli.SchemaTag{{Name: "Open", Color: "red"}}}},
+		Types:      []anytypecli.SchemaType{{Key: "a/b", Templates: []anytypecli.SchemaTemplate{{Name: "Weekly"}}}},
+	}
+
+	diff := anytypecli.DiffSchemas(from, to)
+	var got []string
+	for _, op := range diff.Patch {
+		got = append(got, op.Op+" "+op.Path)
+	}
+	want := "remove /properties/status/tags/Done, remove /types/a~1b/properties/status, add /types/a~1b/templates/Weekly"
+	if strings.Join(got, ", ") != want {
+		t.Errorf("patch = %v, want %s", got, want)
+	}
+
+	if !anytypecli.DiffSchemas(to, to).Empty() {
+		t.Error("a schema differs from itself")
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	text := "{{date}} {{ who }}: {{unknown}} {{who}}"
+	if got := anytypecli.Placeholders(text, "{{date}}"); strings.Join(got, ",") != "date,unknown,who" {
+		t.Errorf("Placeholders = %v", got)
+	}
+	vars := anytypecli.TemplateVariables(time.Date(2027, time.January, 1, 8, 5, 0, 0, time.UTC))
+	vars["who"] = "Alice"
+	if got, want := anytypecli.RenderTemplate(text, vars), "2027-01-01 Alice: {{unknown}} Alice"; got != want {
+		t.Errorf("RenderTemplate = %q, want %q", got, want)
+	}
+	if week := vars[anytypecli.VarWeek]; week != "2026-W53" {
+		t.Errorf("week = %q, want the ISO week 2026-W53", week)
+	}
+
+	if name, value, err := anytypecli.ParseVariable("attendees=A, B=C"); err != nil || name != "attendees" || value != "A, B=C" {
+		t.Errorf("ParseVariable = %q, %q, %v", name, value, err)
+	}
+	for _, invalid := range []string{"date", "=x", "a b=c"} {
+		if _, _, err := anytypecli.ParseVariable(invalid); err == nil {
+			t.Errorf("ParseVariable(%q) succeeded", invalid)
+		}
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	for expr, want := range map[string]anytype.ListFilter{
+		"status = Done":            {PropertyKey: "status", Condition: anytypecli.ConditionEqual, Value: "Done"},
+		"due_date<=today+7d":       {PropertyKey: "due_date", Condition: anytypecli.ConditionLessOrEqual, Value: "today+7d"},
+		`name !~ "a = b"`:          {PropertyKey: "name", Condition: anytypecli.ConditionNotContains, Value: "a = b"},
+		"description is not empty": {PropertyKey: "description", Condition: anytypecli.ConditionNotEmpty},
+	} {
+		got, err := anytypecli.ParseFilter(expr)
+		if err != nil || got != want {
+			t.Errorf("ParseFilter(%q) = %+v, %v, want %+v", expr, got, err, want)
+			continue
+		}
+		if again, err := anytypecli.ParseFilter(anytypecli.FormatFilter(got)); err != nil || again.Condition != got.Condition {
+			t.Errorf("FormatFilter(%+v) = %q does not parse back", got, anytypecli.FormatFilter(got))
+		}
+	}
+	for _, invalid := range []string{"status", "= Done", "due date < today", "status ="} {
+		if _, err := anytypecli.ParseFilter(invalid); err == nil {
+			t.Errorf("ParseFilter(%q) succeeded", invalid)
+		}
+	}
+}
+
+func TestMatchFiltersAndSort(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 23, 0, 0, 0, time.UTC)
+	task := func(name, status, due string, points float64) anytype.Object {
+		return anytype.Object{Name: name, Properties: []anytype.Property{
+			{Key: "status", Format: "select", Select: &anytype.Tag{Name: status}},
+			{Key: "due", Format: "date", Date: due},
+			{Key: "points", Format: "number", Number: points},
+		}}
+	}
+	objects := []anytype.Object{
+		task("a", "Done", "2026-10-15T00:00:00Z", 8),
+		task("b", "Open", "2026-10-16T00:00:00Z", 10),
+		task("c", "Open", "", 2),
+	}
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"status = open", "b c"},
+		{"due < today", "a"},
+		{"due = today", "b"},
+		{"due >= yesterday", "a b"},
+		{"due is empty", "c"},
+		{"points > 9", "b"},
+		{"name != b", "a c"},
+	}
+	for _, tt := range tests {
+		filter, err := anytypecli.ParseFilter(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, obj := range objects {
+			if anytypecli.MatchFilters(obj, []anytype.ListFilter{filter}, now) {
+				got = append(got, obj.Name)
+			}
+		}
+		if strings.Join(got, " ") != tt.want {
+			t.Errorf("%s: matched %v, want %s", tt.expr, got, tt.want)
+		}
+	}
+
+	anytypecli.SortObjects(objects, []anytype.ListSort{{PropertyKey: "status", SortType: "desc"}, {PropertyKey: "due", SortType: "asc"}}, now)
+	var got []string
+	for _, obj := range objects {
+		got = append(got, obj.Name)
+	}
+	if strings.Join(got, " ") != "b c a" {
+		t.Errorf("sorted = %v, want b c a", got)
+	}
+}
+
+func TestGroupObjects(t *testing.T) {
+	tagged := func(name string, tags ...string) anytype.Object {
+		property := anytype.Property{Key: "labels", Format: "multi_select"}
+		for _, tag := range tags {
+			property.MultiSelect = append(property.MultiSelect, anytype.Tag{Name: tag})
+		}
+		return anytype.Object{Name: name, Properties: []anytype.Property{property}}
+	}
+	objects := []anytype.Object{tagged("a", "bug"), tagged("b", "Docs", "Bug"), tagged("c"), tagged("d", "ux")}
+
+	var got []string
+	for _, group := range anytypecli.GroupObjects(objects, "labels", []string{"Bug", "Docs", "Later"}) {
+		var names []string
+		for _, obj := range group.Objects {
+			names = append(names, obj.Name)
+		}
+		got = append(got, group.Name+":"+strings.Join(names, ","))
+	}
+	if want := "Bug:a,b Docs:b Later: ux:d :c"; strings.Join(got, " ") != want {
+		t.Errorf("groups = %v, want %s", got, want)
+	}
+
+	if keys := anytypecli.PropertyKeys(objects); strings.Join(keys, ",") != "labels" {
+		t.Errorf("PropertyKeys = %v", keys)
+	}
+}
+
+func TestReadIDsAndDiff(t *testing.T) {
+	input := "obj-a\n\n  obj-b  \n{\"ID\": \"obj-c\", \"name\": \"C\"}\nobj-a\n"
+	ids, err := anytypecli.ReadIDs(strings.NewReader(input))
+	if err != nil || strings.Join(ids, ",") != "obj-a,obj-b,obj-c" {
+		t.Errorf("ReadIDs = %v, %v", ids, err)
+	}
+	if _, err := anytypecli.ReadIDs(strings.NewReader("obj-a\n{\"name\": \"C\"}\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ReadIDs without id field: err = %v", err)
+	}
+
+	add, remove := anytypecli.DiffIDs([]string{"obj-a", "obj-b"}, ids)
+	if strings.Join(add, ",") != "obj-c" || strings.Join(remove, ",") != "" {
+		t.Errorf("DiffIDs = %v, %v", add, remove)
+	}
+}
+
+func TestCheckLastOwner(t *testing.T) {
+	alice := anytype.Member{ID: "alice", Name: "Alice", Role: "owner", Status: "active"}
+	bob := anytype.Member{ID: "bob", Name: "Bob", Role: "owner", Status: "joining"}
+	members := []anytype.Member{alice, bob}
+
+	if err := anytypecli.CheckLastOwner(members, alice, "editor"); err == nil {
+		t.Error("demoting the last active owner succeeded")
+	}
+	if err := anytypecli.CheckLastOwner(members, alice, "owner"); err != nil {
+		t.Errorf("keeping the owner role: %v", err)
+	}
+	bob.Status = "active"
+	if err := anytypecli.CheckLastOwner([]anytype.Member{alice, bob}, alice, ""); err != nil {
+		t.Errorf("removing one of two owners: %v", err)
+	}
+	if anytypecli.CompareRoles("viewer", "editor") >= 0 || anytypecli.CompareRoles("owner", "editor") <= 0 {
+		t.Error("CompareRoles does not order viewer < editor < owner")
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 9, 30, 0, 0, time.UTC)
+	dated := func(id, name, typeKey, created, modified string) anytype.Object {
+		return anytype.Object{ID: id, Name: name, TypeKey: typeKey, Layout: "basic", Properties: []anytype.Property{
+			{Key: anytypecli.CreatedDateProperty, Format: "date", Date: created},
+			{Key: anytypecli.LastModifiedDateProperty, Format: "date", Date: modified},
+		}}
+	}
+	objects := []anytype.Object{
+		dated("a", "A", "ot-page", "2026-10-14T10:00:00Z", "2026-10-15T10:00:00Z"),
+		dated("b", "", "ot-page", "2026-10-01T10:00:00Z", "2026-10-12T08:00:00Z"),
+		dated("c", "C", "ot-note", "2025-01-01T10:00:00Z", "2026-10-07T10:00:00Z"),
+		{ID: "d", Name: "D", TypeKey: "ot-page", Archived: true},
+		{ID: "e", Name: "E", TypeKey: "ot-collection", Layout: "collection"},
+	}
+	members := []anytype.Member{{Role: "owner", Status: "active"}, {Role: "viewer", Status: "joining"}}
+
+	stats := anytypecli.ComputeStats(objects, members, map[string]int{"a": 10, "b": 30, "c": 20}, 2, now, 2)
+	if stats.Objects != 4 || stats.Archived != 1 || stats.Lists != 1 {
+		t.Errorf("counts = %d objects, %d archived, %d lists", stats.Objects, stats.Archived, stats.Lists)
+	}
+	if got := fmt.Sprint(stats.ByType); got != "[{ot-page 2} {ot-collection 1} {ot-note 1}]" {
+		t.Errorf("ByType = %s", got)
+	}
+	if got := fmt.Sprint(stats.MembersByRole); got != "[{owner 1}]" {
+		t.Errorf("MembersByRole = %s", got)
+	}
+	if got := fmt.Sprint(stats.Weeks); got != "[{2026-W41 0 1} {2026-W42 1 2}]" {
+		t.Errorf("Weeks = %s", got)
+	}
+	if len(stats.Largest) != 2 || stats.Largest[0].ID != "b" || stats.Largest[1].ID != "c" {
+		t.Errorf("Largest = %v", stats.Largest)
+	}
+	if len(stats.Untitled) != 1 || stats.Untitled[0].ID != "b" {
+		t.Errorf("Untitled = %v", stats.Untitled)
+	}
+}
+
+func TestCopierCreateMissing(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+	c, api := newClient(srv)
+	ctx := context.Background()
+
+	cp := anytypecli.NewCopier(c, api, testserver.SpaceEngineering, testserver.SpacePersonal)
+	obj, err := cp.Read(ctx, testserver.ObjectWriteDocs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var missing *anytypecli.MissingError
+	if _, err := cp.Copy(ctx, *obj); !errors.As(err, &missing) || missing.Resource != "type" || missing.Name != "ot-task" {
+		t.Fatalf("Copy() error = %v, want the missing type ot-task", err)
+	}
+
+	cp.CreateMissing = true
+	copied, err := cp.Copy(ctx, *obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copied.TypeKey != "ot-task" || cp.IDs[testserver.ObjectWriteDocs] != copied.ID {
+		t.Errorf("copy = %s of type %s, IDs = %v", copied.ID, copied.TypeKey, cp.IDs)
+	}
+	if len(copied.Properties) != 2 || copied.Properties[0].Select == nil || copied.Properties[0].Select.Name != "In Progress" {
+		t.Errorf("copied properties = %+v, want the status and due date", copied.Properties)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30d", 30 * 24 * time.Hour, false},
+		{"2W", 14 * 24 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{"-1h", 0, true},
+		{"a month", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := anytypecli.ParseAge(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, %v; want %v (error: %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestAssignProperty(t *testing.T) {
+	srv := testserver.New()
+	defer srv.Close()
+	_, api := newClient(srv)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		in      string
+		check   func(anytype.Property) bool
+		wantErr bool
+	}{
+		{"status=in progress", func(p anytype.Property) bool { return p.Select != nil && p.Select.ID == testserver.TagInProgress }, false},
+		{"Due date=today+14d", func(p anytype.Property) bool { return p.Date == "2026-10-30T00:00:00Z" }, false},
+		{"done=true", func(p anytype.Property) bool { return p.Checkbox }, false},
+		{"description=", func(p anytype.Property) bool { return anytypecli.PropertyValue(p) == nil }, false},
+		{"done=maybe", nil, true},
+		{"status=Planned", nil, true},
+	}
+	for _, tt := range tests {
+		assignment, err := anytypecli.ParseAssignment(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		property, err := anytypecli.AssignProperty(ctx, api, testserver.SpaceEngineering, assignment, now)
+		if (err != nil) != tt.wantErr || (err == nil && !tt.check(property)) {
+			t.Errorf("AssignProperty(%q) = %+v, %v", tt.in, property, err)
+		}
+	}
+
+	properties := anytypecli.SetProperty([]anytype.Property{{Key: "done"}, {Key: "status"}}, anytype.Property{Key: "status", Text: "x"})
+	if len(properties) != 2 || properties[1].Text != "x" {
+		t.Errorf("SetProperty() = %+v", properties)
+	}
+}
+
+func TestObjectEditUpdate(t *testing.T) {
+	status := anytype.Property{Key: "status", Format: "select"}
+	labels := anytype.Property{Key: "labels", Format: "multi_select"}
+	done := anytype.Tag{ID: testserver.TagDone, Name: "Done"}
+	urgent := anytype.Tag{ID: "tag-urgent", Name: "Urgent"}
+	obj := anytype.Object{ID: "obj", Properties: []anytype.Property{
+		{Key: "status", Format: "select", Select: &done},
+		{Key: "labels", Format: "multi_select", MultiSelect: []anytype.Tag{urgent}},
+	}}
+
+	if _, changed := (anytypecli.ObjectEdit{AddTags: []anytypecli.TagChange{{Property: status, Tag: done}}}).Update(obj); changed {
+		t.Error("setting the current tag changes the object")
+	}
+	req, changed := anytypecli.ObjectEdit{
+		RemoveTags: []anytypecli.TagChange{{Property: status, Tag: done}, {Property: labels, Tag: urgent}},
+		TypeKey:    "ot-page",
+	}.Update(obj)
+	if !changed || req.TypeKey != "ot-page" || len(req.Properties) != 2 {
+		t.Fatalf("Update() = %+v, %v", req, changed)
+	}
+	if got := fmt.Sprint(req.Properties); got != "[map[key:status select:<nil>] map[key:labels multi_select:[]]]" {
+		t.Errorf("Update() properties = %s", got)
+	}
+
+	results := anytypecli.ForEachConcurrent(context.Background(), []string{"a", "b", "c"}, 2, func(ctx context.Context, id string) error {
+		if id == "b" {
+			return errors.New("failed")
+		}
+		return nil
+	}, nil)
+	if !slices.Equal(results.Succeeded(), []string{"a", "c"}) || len(results.Failed()) != 1 {
+		t.Errorf("ForEachConcurrent() = %+v", results)
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	objects := []anytype.Object{
+		{ID: "a", Name: "Old", Layout: "basic", Properties: []anytype.Property{
+			{Key: anytypecli.CreatedDateProperty, Format: "date", Date: "2026-09-01T10:00:00Z"},
+			{Key: "labels", Format: "multi_select", MultiSelect: []anytype.Tag{{Name: "Urgent"}}},
+		}},
+		{ID: "b", Name: "New", Layout: "basic", Properties: []anytype.Property{
+			{Key: anytypecli.CreatedDateProperty, Format: "date", Date: "2026-10-12T10:00:00Z"},
+		}},
+		{ID: "c", Name: "Task", Layout: "action", Properties: []anytype.Property{
+			{Key: anytypecli.CreatedDateProperty, Format: "date", Date: "2026-10-14T10:00:00Z"},
+			{Key: "status", Format: "select", Select: &anytype.Tag{Name: "Urgent"}},
+		}},
+	}
+
+	q := anytypecli.SearchQuery{
+		Filters: []anytype.ListFilter{{PropertyKey: anytypecli.CreatedDateProperty, Condition: anytypecli.ConditionGreater, Value: "today-7d"}},
+		Sorts:   []anytype.ListSort{{PropertyKey: "name", SortType: anytypecli.SortAscending}},
+		Layouts: []string{"basic", "action"},
+	}
+	var matched []anytype.Object
+	for _, obj := range objects {
+		if q.Match(obj, now) {
+			matched = append(matched, obj)
+		}
+	}
+	if req := q.Request(); req.Sort == nil || req.Sort.Property != anytype.SortPropertyName {
+		t.Errorf("Request() = %+v, want the sort by name", req)
+	}
+	q.Sorts = []anytype.ListSort{{PropertyKey: "labels", SortType: anytypecli.SortAscending}, {PropertyKey: "name", SortType: anytypecli.SortDescending}}
+	q.Sort(matched, now)
+	if len(matched) != 2 || matched[0].ID != "c" || matched[1].ID != "b" {
+		t.Errorf("matched %+v, want c then b", matched)
+	}
+
+	for id, want := range map[int]bool{0: true, 1: false, 2: true} {
+		if got := anytypecli.HasTag(objects[id], "urgent"); got != want {
+			t.Errorf("HasTag(%s) = %v, want %v", objects[id].ID, got, want)
+		}
+	}
+}
+
+func TestMatchFiltersAgeInHours(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	modifiedAt := func(date string) anytype.Object {
+		return anytype.Object{Properties: []anytype.Property{{Key: anytypecli.LastModifiedDateProperty, Format: "date", Date: date}}}
+	}
+	age, err := anytypecli.ParseAge("6h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filters := []anytype.ListFilter{{PropertyKey: anytypecli.LastModifiedDateProperty, Condition: anytypecli.ConditionGreaterOrEqual,
+		Value: now.Add(-age).Format(time.RFC3339)}}
+
+	for date, want := range map[string]bool{
+		"2026-10-16T08:00:00Z": true,
+		"2026-10-16T03:30:00Z": true,
+		"2026-10-16T03:00:00Z": false, // the same day, but more than 6 hours ago
+		"2026-10-15T23:00:00Z": false,
+	} {
+		if got := anytypecli.MatchFilters(modifiedAt(date), filters, now); got != want {
+			t.Errorf("modified at %s: MatchFilters() = %v, want %v", date, got, want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package anytypecli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/epheo/anytype-go"
+)
+
+// APIVersion is the version of the Anytype API sent with every request
+const APIVersion = "2025-05-20"
+
+// API performs requests against the Anytype API directly. It covers what the
+// SDK client does not: paginated listings and the endpoints the SDK has no
+// method for.
+type API struct {
+	// BaseURL of the Anytype API, without the /v1 prefix
+	BaseURL string
+	// AppKey sent as the bearer token
+	AppKey string
+	// HTTPClient performs the requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+// Service is the direct API access the operations of this package are built on, one
+// method per endpoint. API implements it against the Anytype API; a test or an embedding
+// program may provide its own, as it may for anytype.Client.
+type Service interface {
+	Spaces(ctx context.Context) iter.Seq2[anytype.Space, error]
+	CreateSpace(ctx context.Context, req anytype.CreateSpaceRequest) (*anytype.Space, error)
+	UpdateSpace(ctx context.Context, spaceID string, req UpdateSpaceRequest) (*anytype.Space, error)
+	Members(ctx context.Context, spaceID string) iter.Seq2[anytype.Member, error]
+	UpdateMember(ctx context.Context, spaceID, memberID string, req UpdateMemberRequest) (*anytype.Member, error)
+
+	Objects(ctx context.Context, spaceID string) iter.Seq2[anytype.Object, error]
+	ArchivedObjects(ctx context.Context, spaceID string) ([]anytype.Object, error)
+	Search(ctx context.Context, spaceID string, req anytype.SearchRequest) iter.Seq2[anytype.Object, error]
+	CreateObject(ctx context.Context, spaceID string, req CreateObjectRequest) (*anytype.Object, error)
+	UpdateObject(ctx context.Context, spaceID, objectID string, req UpdateObjectRequest) (*anytype.Object, error)
+	CreateTemplate(ctx context.Context, spaceID, typeKey string, req CreateTemplateRequest) (*anytype.Object, error)
+
+	Lists(ctx context.Context, spaceID string) iter.Seq2[anytype.Object, error]
+	CreateList(ctx context.Context, spaceID string, req CreateListRequest) (*anytype.Object, error)
+	ListObjects(ctx context.Context, spaceID, listID, viewID string) iter.Seq2[anytype.Object, error]
+	CollectionObjects(ctx context.Context, spaceID, listID string) iter.Seq2[anytype.Object, error]
+
+	Types(ctx context.Context, spaceID string) iter.Seq2[anytype.Type, error]
+	UpdateType(ctx context.Context, spaceID, typeID string, req UpdateTypeRequest) (*anytype.Type, error)
+	DeleteType(ctx context.Context, spaceID, typeID string) (*anytype.Type, error)
+
+	Properties(ctx context.Context, spaceID string) iter.Seq2[Property, error]
+	GetProperty(ctx context.Context, spaceID, propertyID string) (*Property, error)
+	CreateProperty(ctx context.Context, spaceID string, req CreatePropertyRequest) (*Property, error)
+	UpdateProperty(ctx context.Context, spaceID, propertyID string, req UpdatePropertyRequest) (*Property, error)
+	DeleteProperty(ctx context.Context, spaceID, propertyID string) (*Property, error)
+	Tags(ctx context.Context, spaceID, propertyID string) iter.Seq2[anytype.Tag, error]
+	GetTag(ctx context.Context, spaceID, propertyID, tagID string) (*anytype.Tag, error)
+	CreateTag(ctx context.Context, spaceID, propertyID string, req CreateTagRequest) (*anytype.Tag, error)
+	UpdateTag(ctx context.Context, spaceID, propertyID, tagID string, req UpdateTagRequest) (*anytype.Tag, error)
+	DeleteTag(ctx context.Context, spaceID, propertyID, tagID string) (*anytype.Tag, error)
+}
+
+var _ Service = (*API)(nil)
+
+// NewAPI returns an API sending requests to baseURL with the given app key
+func NewAPI(baseURL, appKey string) *API {
+	return &API{BaseURL: baseURL, AppKey: appKey}
+}
+
+// StatusError is returned when the API answers with a non-2xx status. Its message
+// has the same form as the errors of the SDK.
+type StatusError struct {
+	Status int
+	Body   string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request failed with status %d: %s", e.Status, e.Body)
+}
+
+// Do sends a request to the given API path (relative to /v1) and decodes the JSON
+// response into result, unless result is nil. body, if not nil, is sent as JSON.
+func (a *API) Do(ctx context.Context, method, apiPath string, query url.Values, body, result interface{}) error {
+	u, err := url.Parse(a.BaseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base URL: %w", err)
+	}
+	u.Path = path.Join(u.Path, "/v1", apiPath)
+	u.RawQuery = query.Encode()
+
+	var bodyReader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		bodyReader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bodyReader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Anytype-Version", APIVersion)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if a.AppKey != "" {
+		req.Header.Set("Authorization", "Bearer "+a.AppKey)
+	}
+
+	httpClient := a.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return &StatusError{Status: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,56 @@
+package anytypecli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/epheo/anytype-go"
+)
+
+// ArchivedObjects returns the archived objects of a space. They are read from the archive
+// object of the space, given by its ArchiveID; if the space has none, or the API does not
+// list it, they are the objects flagged as archived among those of the space.
+func (a *API) ArchivedObjects(ctx context.Context, spaceID string) ([]anytype.Object, error) {
+	var resp spaceResponse
+	if err := a.Do(ctx, "GET", fmt.Sprintf("/spaces/%s", spaceID), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Space.ArchiveID != "" {
+		objects, err := Collect(a.CollectionObjects(ctx, spaceID, resp.Space.ArchiveID))
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) || statusErr.Status != 404 {
+			return objects, err
+		}
+	}
+
+	objects, err := AllObjects(ctx, a, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	archived := []anytype.Object{}
+	for _, obj := range objects {
+		if obj.Archived {
+			archived = append(archived, obj)
+		}
+	}
+	return archived, nil
+}
+
+// ModifiedBefore returns the objects last modified before t. Archiving an object modifies
+// it, so for archived objects this is the time they were archived at the latest. Objects
+// without a valid modification date are left out.
+func ModifiedBefore(objects []anytype.Object, t time.Time) []anytype.Object {
+	before := []anytype.Object{}
+	for _, obj := range objects {
+		values, _ := propertyValues(obj, LastModifiedDateProperty)
+		if len(values) == 0 {
+			continue
+		}
+		if modified, err := time.Parse(time.RFC3339, values[0]); err == nil && modified.Before(t) {
+			before = append(before, obj)
+		}
+	}
+	return before
+}
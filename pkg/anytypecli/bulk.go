@@ -0,0 +1,187 @@
+package anytypecli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/epheo/anytype-go"
+)
+
+// BulkResult is the outcome of a bulk operation for one object
+type BulkResult struct {
+	ID  string
+	Err error
+}
+
+// BulkResults holds the outcome of a bulk operation, one entry per object in input order
+type BulkResults []BulkResult
+
+// Succeeded returns the IDs of the objects the operation was applied to
+func (r BulkResults) Succeeded() []string {
+	ids := []string{}
+	for _, result := range r {
+		if result.Err == nil {
+			ids = append(ids, result.ID)
+		}
+	}
+	return ids
+}
+
+// Failed returns the results of the objects the operation failed for
+func (r BulkResults) Failed() BulkResults {
+	var failed BulkResults
+	for _, result := range r {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err joins the errors of the failed objects, or returns nil if none failed
+func (r BulkResults) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("%s: %w", result.ID, result.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// ForEach applies fn to every ID in turn and records its outcome. A failure does not
+// stop the remaining IDs from being processed, but a cancelled context does: the
+// remaining IDs are recorded with the context error.
+func ForEach(ctx context.Context, ids []string, fn func(ctx context.Context, id string) error) BulkResults {
+	results := make(BulkResults, 0, len(ids))
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			results = append(results, BulkResult{ID: id, Err: err})
+			continue
+		}
+		results = append(results, BulkResult{ID: id, Err: fn(ctx, id)})
+	}
+	return results
+}
+
+// ForEachConcurrent is ForEach running fn on up to workers IDs at a time. done, if not
+// nil, is called with each result as it completes, one call at a time. Results are in
+// input order.
+func ForEachConcurrent(ctx context.Context, ids []string, workers int, fn func(ctx context.Context, id string) error, done func(BulkResult)) BulkResults {
+	results := make(BulkResults, len(ids))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, max(workers, 1))
+	for i, id := range ids {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			err := ctx.Err()
+			if err == nil {
+				err = fn(ctx, id)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			results[i] = BulkResult{ID: id, Err: err}
+			if done != nil {
+				done(results[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// DeleteObjects deletes (archives) each of the given objects of a space
+func DeleteObjects(ctx context.Context, c anytype.Client, spaceID string, objectIDs []string) BulkResults {
+	return ForEach(ctx, objectIDs, func(ctx context.Context, id string) error {
+		_, err := c.Space(spaceID).Object(id).Delete(ctx)
+		return err
+	})
+}
+
+// AddToList adds objects to a list, sending them in batches of batchSize objects
+// (DefaultPageSize if zero or less). Objects of a failed batch all report its error.
+func AddToList(ctx context.Context, c anytype.Client, spaceID, listID string, objectIDs []string, batchSize int) BulkResults {
+	if batchSize <= 0 {
+		batchSize = DefaultPageSize
+	}
+
+	results := make(BulkResults, 0, len(objectIDs))
+	for start := 0; start < len(objectIDs); start += batchSize {
+		batch := objectIDs[start:min(start+batchSize, len(objectIDs))]
+		err := ctx.Err()
+		if err == nil {
+			err = c.Space(spaceID).List(listID).Objects().Add(ctx, batch)
+		}
+		for _, id := range batch {
+			results = append(results, BulkResult{ID: id, Err: err})
+		}
+	}
+	return results
+}
+
+// RemoveFromList removes each of the given objects from a list
+func RemoveFromList(ctx context.Context, c anytype.Client, spaceID, listID string, objectIDs []string) BulkResults {
+	return ForEach(ctx, objectIDs, func(ctx context.Context, id string) error {
+		return c.Space(spaceID).List(listID).Object(id).Remove(ctx)
+	})
+}
+
+// ReadIDs reads object IDs, one per line. A line holding a JSON object, as written by
+// NDJSON tools, gives the value of its "id" field (in any case). Blank lines are skipped
+// and duplicate IDs are dropped.
+func ReadIDs(r io.Reader) ([]string, error) {
+	var ids []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		id := text
+		if strings.HasPrefix(text, "{") {
+			var fields map[string]interface{}
+			if err := json.Unmarshal([]byte(text), &fields); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			id = ""
+			for key, value := range fields {
+				if strings.EqualFold(key, "id") {
+					id, _ = value.(string)
+				}
+			}
+			if id == "" {
+				return nil, fmt.Errorf("line %d: no id field", line)
+			}
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, scanner.Err()
+}
+
+// DiffIDs returns the IDs of wanted that are not in current, and those of current that
+// are not in wanted, in order
+func DiffIDs(current, wanted []string) (add, remove []string) {
+	add, remove = []string{}, []string{}
+	for _, id := range wanted {
+		if !slices.Contains(current, id) {
+			add = append(add, id)
+		}
+	}
+	for _, id := range current {
+		if !slices.Contains(wanted, id) {
+			remove = append(remove, id)
+		}
+	}
+	return add, remove
+}
@@ -0,0 +1,179 @@
+package anytypecli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/epheo/anytype-go"
+)
+
+// CloneOptions controls what CloneSpace creates
+type CloneOptions struct {
+	Name string
+	// Description and Icon default to those of the source space
+	Description string
+	Icon        *anytype.Icon
+	// StructureOnly copies the types, properties, tags, templates and lists but no objects
+	StructureOnly bool
+}
+
+// CloneIssue is something CloneSpace could not copy
+type CloneIssue struct {
+	// Resource is "schema", "list", "view", "object" or "link"
+	Resource string `json:"resource" yaml:"resource"`
+	Name     string `json:"name" yaml:"name"`
+	Reason   string `json:"reason" yaml:"reason"`
+}
+
+// CloneReport describes a cloned space and what was copied into it
+type CloneReport struct {
+	Space anytype.Space `json:"space" yaml:"space"`
+	// SchemaChanges is the number of types, properties and tags created or updated
+	SchemaChanges int `json:"schema_changes" yaml:"schema_changes"`
+	Templates     int `json:"templates" yaml:"templates"`
+	Lists         int `json:"lists" yaml:"lists"`
+	Objects       int `json:"objects" yaml:"objects"`
+	// LinksRemapped is the number of copied objects whose links were pointed at the copies
+	LinksRemapped int          `json:"links_remapped" yaml:"links_remapped"`
+	Issues        []CloneIssue `json:"issues" yaml:"issues"`
+}
+
+// CloneSpace creates a new space with the types, properties, tags, templates and lists of
+// a source space and, unless opts.StructureOnly is set, copies of its objects with their
+// links and collection membership remapped to the copies. Resources that cannot be copied,
+// such as views the API cannot create, are reported as issues rather than stopping the
+// clone. An error is returned if the space or its schema cannot be created; the report
+// then describes what was done so far.
+func CloneSpace(ctx context.Context, c anytype.Client, a Service, sourceID string, opts CloneOptions) (*CloneReport, error) {
+	report := &CloneReport{Issues: []CloneIssue{}}
+	issue := func(resource, name string, err error) {
+		report.Issues = append(report.Issues, CloneIssue{Resource: resource, Name: name, Reason: err.Error()})
+	}
+
+	source, err := c.Space(sourceID).Get(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to get space: %w", err)
+	}
+	if opts.Description == "" {
+		opts.Description = source.Space.Description
+	}
+	if opts.Icon == nil {
+		opts.Icon = source.Space.Icon
+	}
+	space, err := a.CreateSpace(ctx, anytype.CreateSpaceRequest{Name: opts.Name, Description: opts.Description, Icon: opts.Icon})
+	if err != nil {
+		return report, fmt.Errorf("failed to create space: %w", err)
+	}
+	report.Space = *space
+
+	// Built-in types and properties are included, so that their customizations and the
+	// tags of built-in properties are copied too
+	schema, err := ExportSchema(ctx, c, a, sourceID, ExportSchemaOptions{All: true})
+	if err != nil {
+		return report, err
+	}
+	plan, err := PlanSchema(ctx, c, a, space.ID, schema)
+	if err != nil {
+		return report, err
+	}
+	// The schema carries the templates with their body, so applying it copies them too
+	applied, err := plan.Apply(ctx)
+	if err != nil {
+		report.SchemaChanges = applied
+		return report, err
+	}
+	report.Templates = plan.CountResource(ActionCreate, "template")
+	report.SchemaChanges = applied - report.Templates
+
+	copier := NewCopier(c, a, sourceID, space.ID)
+	copier.CreateMissing = true
+	lists, err := Collect(a.Lists(ctx, sourceID))
+	if err != nil {
+		return report, fmt.Errorf("failed to list lists: %w", err)
+	}
+	for _, list := range lists {
+		sourceTypes, _ := propertyValues(list, SetSourceProperty)
+		created, err := a.CreateList(ctx, space.ID, CreateListRequest{Name: list.Name, Kind: ListKind(list), SourceTypes: sourceTypes, Icon: list.Icon})
+		if err != nil {
+			issue("list", list.Name, err)
+			continue
+		}
+		copier.IDs[list.ID] = created.ID
+		report.Lists++
+		for _, view := range missingViews(ctx, c, sourceID, list.ID, space.ID, created.ID) {
+			report.Issues = append(report.Issues, CloneIssue{Resource: "view", Name: list.Name + " / " + view.Name,
+				Reason: fmt.Sprintf("%s view, views cannot be created or edited through the API", view.Layout)})
+		}
+	}
+	if opts.StructureOnly {
+		return report, nil
+	}
+
+	objects, err := AllObjects(ctx, a, sourceID)
+	if err != nil {
+		return report, fmt.Errorf("failed to list objects: %w", err)
+	}
+	for _, obj := range objects {
+		if obj.Archived || !Copyable(obj) {
+			continue
+		}
+		content, err := copier.Read(ctx, obj.ID)
+		if err == nil {
+			_, err = copier.Copy(ctx, *content)
+		}
+		if err != nil {
+			issue("object", obj.Name, err)
+			continue
+		}
+		report.Objects++
+	}
+
+	for _, list := range lists {
+		if ListKind(list) != ListKindCollection || copier.IDs[list.ID] == "" {
+			continue
+		}
+		members, err := Collect(a.CollectionObjects(ctx, sourceID, list.ID))
+		if err != nil {
+			issue("list", list.Name, err)
+			continue
+		}
+		var ids []string
+		for _, member := range members {
+			if id, ok := copier.IDs[member.ID]; ok {
+				ids = append(ids, id)
+			}
+		}
+		if err := AddToList(ctx, c, space.ID, copier.IDs[list.ID], ids, 0).Err(); err != nil {
+			issue("list", list.Name, err)
+		}
+	}
+
+	if report.LinksRemapped, err = copier.RemapLinks(ctx); err != nil {
+		issue("link", "", err)
+	}
+	return report, nil
+}
+
+// missingViews returns the views of a source list that the copy of the list lacks, or
+// has with other filters or sorts. Views are matched by name and layout.
+func missingViews(ctx context.Context, c anytype.Client, sourceID, listID, spaceID, copyID string) []anytype.ListView {
+	views, err := c.Space(sourceID).List(listID).Views().List(ctx)
+	if err != nil {
+		return nil
+	}
+	copied, err := c.Space(spaceID).List(copyID).Views().List(ctx)
+	if err != nil {
+		return views.Data
+	}
+	var missing []anytype.ListView
+	for _, view := range views.Data {
+		if !slices.ContainsFunc(copied.Data, func(other anytype.ListView) bool {
+			return other.Name == view.Name && other.Layout == view.Layout &&
+				len(other.Filters) == len(view.Filters) && len(other.Sorts) == len(view.Sorts)
+		}) {
+			missing = append(missing, view)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,361 @@
+package anytypecli
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/epheo/anytype-go"
+)
+
+// MissingError is returned by a Copier when the destination space lacks a type, property
+// or tag of a copied object and CreateMissing is not set
+type MissingError struct {
+	// Resource is "type", "property" or "tag"
+	Resource string
+	// Name is the key of a type or property, or the name of a tag
+	Name string
+}
+
+func (e *MissingError) Error() string {
+	return fmt.Sprintf("%s '%s' does not exist in the destination space", e.Resource, e.Name)
+}
+
+// Copier copies objects from one space to another, or within a space. A copy has the type,
+// name, icon, body and property values of the original; select values are matched by tag
+// name, and links to other copied objects can be pointed at their copies with RemapLinks.
+type Copier struct {
+	// CreateMissing creates the types, properties and tags that the destination space
+	// lacks; otherwise copying an object that uses one fails with a *MissingError
+	CreateMissing bool
+	// IDs maps the IDs of the copied objects to the IDs of their copies. Callers may add
+	// the objects they copy by other means, such as lists, so that links to them are remapped.
+	IDs map[string]string
+
+	c        anytype.Client
+	a        Service
+	from, to string
+
+	types      map[string]bool              // type keys of the destination
+	properties map[string]Property          // properties of the destination by key
+	tags       map[string]map[string]string // destination property key -> lowercase tag name -> tag ID
+	copies     map[string]*anytype.Object   // copy ID -> content of the copy as created
+}
+
+// NewCopier returns a Copier from the space from to the space to, which may be the same
+func NewCopier(c anytype.Client, a Service, from, to string) *Copier {
+	return &Copier{IDs: map[string]string{}, c: c, a: a, from: from, to: to, copies: map[string]*anytype.Object{}}
+}
+
+// Read returns an object of the source space, with its body in Markdown
+func (cp *Copier) Read(ctx context.Context, objectID string) (*anytype.Object, error) {
+	resp, err := cp.c.Space(cp.from).Object(objectID).Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	obj := *resp.Object
+	if obj.Markdown, err = ExportMarkdown(ctx, cp.c, cp.from, objectID); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// Copy creates a copy of an object read with Read, possibly changed by the caller, in the
+// destination space and returns it
+func (cp *Copier) Copy(ctx context.Context, obj anytype.Object) (*anytype.Object, error) {
+	if err := cp.ensureType(ctx, obj.TypeKey); err != nil {
+		return nil, err
+	}
+	var properties []anytype.Property
+	for _, property := range obj.Properties {
+		if PropertyValue(property) == nil {
+			continue
+		}
+		mapped, err := cp.mapProperty(ctx, property)
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, mapped)
+	}
+
+	created, err := cp.a.CreateObject(ctx, cp.to, CreateObjectRequest{
+		TypeKey:    obj.TypeKey,
+		Name:       obj.Name,
+		Body:       obj.Markdown,
+		Icon:       obj.Icon,
+		Properties: PropertyValues(properties),
+	})
+	if err != nil {
+		return nil, err
+	}
+	cp.IDs[obj.ID] = created.ID
+	cp.copies[created.ID] = &anytype.Object{ID: created.ID, Markdown: obj.Markdown, Properties: properties}
+	return created, nil
+}
+
+// RemapLinks points the links of the copies, in their bodies and object properties, to the
+// copies of the objects they link to, and returns the number of copies it updated
+func (cp *Copier) RemapLinks(ctx context.Context) (int, error) {
+	updated := 0
+	for _, id := range slices.Sorted(maps.Keys(cp.copies)) {
+		copied := cp.copies[id]
+		var req UpdateObjectRequest
+		body := copied.Markdown
+		for from, to := range cp.IDs {
+			body = strings.ReplaceAll(body, from, to)
+		}
+		if body != copied.Markdown {
+			req.Markdown = body
+		}
+		for _, property := range copied.Properties {
+			if property.Format != "objects" {
+				continue
+			}
+			objects := make([]string, len(property.Objects))
+			for i, objectID := range property.Objects {
+				objects[i] = cmp.Or(cp.IDs[objectID], objectID)
+			}
+			if !slices.Equal(objects, property.Objects) {
+				property.Objects = objects
+				req.Properties = append(req.Properties, PropertyValue(property))
+			}
+		}
+		if req.Markdown == "" && req.Properties == nil {
+			continue
+		}
+		if _, err := cp.a.UpdateObject(ctx, cp.to, id, req); err != nil {
+			return updated, fmt.Errorf("failed to update the links of %s: %w", id, err)
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// CopiedObject is an object copied by CopyObjects
+type CopiedObject struct {
+	SourceID string `json:"source_id" yaml:"source_id"`
+	ID       string `json:"id" yaml:"id"`
+	Name     string `json:"name" yaml:"name"`
+	TypeKey  string `json:"type_key" yaml:"type_key"`
+}
+
+// CopyObjects copies objects with cp and the objects they link to, up to depth links
+// away, then points the links between the copies at the copies. It is PlanCopy followed
+// by CopyPlanned.
+func CopyObjects(ctx context.Context, cp *Copier, objectIDs []string, depth int) ([]CopiedObject, error) {
+	objects, err := PlanCopy(ctx, cp, objectIDs, depth)
+	if err != nil {
+		return nil, err
+	}
+	return CopyPlanned(ctx, cp, objects)
+}
+
+// PlanCopy reads the objects with cp and the objects they link to, in turn, up to depth
+// links away, and returns them in the order CopyPlanned copies them. A depth of 0 follows
+// no links and a negative depth follows them all. Linked lists, templates and objects
+// Anytype creates itself, such as members, are not followed.
+func PlanCopy(ctx context.Context, cp *Copier, objectIDs []string, depth int) ([]anytype.Object, error) {
+	var objects []anytype.Object
+	queue := slices.Clone(objectIDs)
+	levels := make([]int, len(queue))
+	seen := map[string]bool{}
+	for i := 0; i < len(queue); i++ {
+		id := queue[i]
+		if seen[id] || cp.IDs[id] != "" {
+			continue
+		}
+		seen[id] = true
+		obj, err := cp.Read(ctx, id)
+		if err != nil {
+			return objects, fmt.Errorf("failed to read object %s: %w", id, err)
+		}
+		if i >= len(objectIDs) && !Copyable(*obj) {
+			continue
+		}
+		objects = append(objects, *obj)
+		if depth < 0 || levels[i] < depth {
+			for _, linked := range LinkedObjectIDs(*obj) {
+				queue = append(queue, linked)
+				levels = append(levels, levels[i]+1)
+			}
+		}
+	}
+	return objects, nil
+}
+
+// CopyPlanned copies objects returned by PlanCopy with cp, then points the links between
+// the copies at the copies. It stops at the first object that cannot be copied, returning
+// the copies made so far.
+func CopyPlanned(ctx context.Context, cp *Copier, objects []anytype.Object) ([]CopiedObject, error) {
+	var copied []CopiedObject
+	for _, obj := range objects {
+		created, err := cp.Copy(ctx, obj)
+		if err != nil {
+			return copied, fmt.Errorf("failed to copy object %s: %w", obj.ID, err)
+		}
+		copied = append(copied, CopiedObject{SourceID: obj.ID, ID: created.ID, Name: created.Name, TypeKey: created.TypeKey})
+	}
+	if _, err := cp.RemapLinks(ctx); err != nil {
+		return copied, err
+	}
+	return copied, nil
+}
+
+// linkIgnoredKeys are the keys of the object properties that do not link to content
+var linkIgnoredKeys = []string{"creator", "last_modified_by", "backlinks", SetSourceProperty, TargetTypeProperty}
+
+// LinkedObjectIDs returns the IDs of the objects an object links to through its object
+// properties, including the links property that Anytype fills from its body
+func LinkedObjectIDs(obj anytype.Object) []string {
+	var ids []string
+	for _, property := range obj.Properties {
+		if property.Format != "objects" || slices.Contains(linkIgnoredKeys, property.Key) {
+			continue
+		}
+		for _, id := range property.Objects {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// copySkippedLayouts are the layouts of the objects Anytype creates itself
+var copySkippedLayouts = []string{"participant", "profile", "space", "date"}
+
+// Copyable reports whether an object is content that can be copied on its own: not a
+// list, whose contents are not copied, a template or an object Anytype creates itself
+func Copyable(obj anytype.Object) bool {
+	return ListKind(obj) == "" && obj.TypeKey != TemplateTypeKey && !slices.Contains(copySkippedLayouts, obj.Layout)
+}
+
+// ensureType checks that the destination has a type, creating it from the source if allowed
+func (cp *Copier) ensureType(ctx context.Context, key string) error {
+	if cp.types == nil {
+		resp, err := cp.c.Space(cp.to).Types().List(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list types: %w", err)
+		}
+		cp.types = map[string]bool{}
+		for _, typ := range resp {
+			cp.types[typ.Key] = true
+		}
+	}
+	if cp.types[key] {
+		return nil
+	}
+	if !cp.CreateMissing {
+		return &MissingError{Resource: "type", Name: key}
+	}
+
+	resp, err := cp.c.Space(cp.from).Type(key).Get(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get type %s: %w", key, err)
+	}
+	typ := resp.Type
+	if _, err := cp.c.Space(cp.to).Types().Create(ctx, anytype.CreateTypeRequest{
+		Key:        typ.Key,
+		Name:       typ.Name,
+		Layout:     typ.Layout,
+		Icon:       typ.Icon,
+		Properties: typ.PropertyDefinitions,
+	}); err != nil {
+		return fmt.Errorf("failed to create type %s: %w", key, err)
+	}
+	cp.types[key] = true
+	// Creating the type may have created properties
+	cp.properties = nil
+	return nil
+}
+
+// mapProperty returns a property value with the tags of the destination
+func (cp *Copier) mapProperty(ctx context.Context, property anytype.Property) (anytype.Property, error) {
+	destination, err := cp.ensureProperty(ctx, property)
+	if err != nil {
+		return property, err
+	}
+	property.ID = destination.ID
+	switch property.Format {
+	case "select":
+		tag := *property.Select
+		if tag.ID, err = cp.tagID(ctx, destination, tag); err != nil {
+			return property, err
+		}
+		property.Select = &tag
+	case "multi_select":
+		tags := slices.Clone(property.MultiSelect)
+		for i := range tags {
+			if tags[i].ID, err = cp.tagID(ctx, destination, tags[i]); err != nil {
+				return property, err
+			}
+		}
+		property.MultiSelect = tags
+	}
+	return property, nil
+}
+
+// ensureProperty returns the property of the destination with the key of property,
+// creating it if allowed
+func (cp *Copier) ensureProperty(ctx context.Context, property anytype.Property) (Property, error) {
+	if cp.properties == nil {
+		properties, err := Collect(cp.a.Properties(ctx, cp.to))
+		if err != nil {
+			return Property{}, fmt.Errorf("failed to list properties: %w", err)
+		}
+		cp.properties = map[string]Property{}
+		for _, p := range properties {
+			cp.properties[p.Key] = p
+		}
+	}
+	if p, ok := cp.properties[property.Key]; ok {
+		return p, nil
+	}
+	if !cp.CreateMissing {
+		return Property{}, &MissingError{Resource: "property", Name: property.Key}
+	}
+
+	created, err := cp.a.CreateProperty(ctx, cp.to, CreatePropertyRequest{Key: property.Key, Name: property.Name, Format: property.Format})
+	if err != nil {
+		return Property{}, fmt.Errorf("failed to create property %s: %w", property.Key, err)
+	}
+	cp.properties[created.Key] = *created
+	return *created, nil
+}
+
+// tagID returns the ID of the tag of a destination property named as tag, creating it if allowed
+func (cp *Copier) tagID(ctx context.Context, property Property, tag anytype.Tag) (string, error) {
+	if cp.tags == nil {
+		cp.tags = map[string]map[string]string{}
+	}
+	if cp.tags[property.Key] == nil {
+		tags, err := Collect(cp.a.Tags(ctx, cp.to, property.ID))
+		if err != nil {
+			return "", fmt.Errorf("failed to list tags of property %s: %w", property.Key, err)
+		}
+		cp.tags[property.Key] = map[string]string{}
+		for _, t := range tags {
+			cp.tags[property.Key][strings.ToLower(t.Name)] = t.ID
+		}
+	}
+	if id, ok := cp.tags[property.Key][strings.ToLower(tag.Name)]; ok {
+		return id, nil
+	}
+	if !cp.CreateMissing {
+		return "", &MissingError{Resource: "tag", Name: tag.Name}
+	}
+
+	color := tag.Color
+	if !ValidTagColor(color) {
+		color = DefaultTagColor
+	}
+	created, err := cp.a.CreateTag(ctx, cp.to, property.ID, CreateTagRequest{Name: tag.Name, Color: color})
+	if err != nil {
+		return "", fmt.Errorf("failed to create tag %s of property %s: %w", tag.Name, property.Key, err)
+	}
+	cp.tags[property.Key][strings.ToLower(created.Name)] = created.ID
+	return created.ID, nil
+}
@@ -0,0 +1,241 @@
+package anytypecli
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// PatchOp is a JSON Patch (RFC 6902) operation
+type PatchOp struct {
+	Op    string      `json:"op" yaml:"op"`
+	Path  string      `json:"path" yaml:"path"`
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// SchemaDiff holds the differences between two schemas, see DiffSchemas
+type SchemaDiff struct {
+	// Patch turns the normalized form of the first schema into the normalized form of the second
+	Patch []PatchOp
+
+	from, to normalizedSchema
+}
+
+// normalizedSchema is the part of a schema that DiffSchemas compares. Its JSON form is
+//
+//	{"properties": {"<key>": {"format": "<format>", "tags": {"<name>": true}}},
+//	 "types": {"<key>": {"properties": {"<key>": "<format>"}, "templates": {"<name>": true}}}}
+//
+// which is the document the paths of SchemaDiff.Patch refer to.
+type normalizedSchema struct {
+	properties map[string]normalizedProperty
+	types      map[string]normalizedType
+}
+
+type normalizedProperty struct {
+	format string
+	tags   map[string]bool
+}
+
+type normalizedType struct {
+	properties map[string]string
+	templates  map[string]bool
+}
+
+// normalize keeps the keys, formats, tag names and template names of a schema
+func normalize(s *Schema) normalizedSchema {
+	n := normalizedSchema{properties: map[string]normalizedProperty{}, types: map[string]normalizedType{}}
+	for _, property := range s.Properties {
+		np := normalizedProperty{format: property.Format, tags: map[string]bool{}}
+		for _, tag := range property.Tags {
+			np.tags[tag.Name] = true
+		}
+		n.properties[property.Key] = np
+	}
+	for _, typ := range s.Types {
+		nt := normalizedType{properties: map[string]string{}, templates: map[string]bool{}}
+		for _, def := range typ.Properties {
+			nt.properties[def.Key] = def.Format
+		}
+		for _, template := range typ.Templates {
+			nt.templates[template.Name] = true
+		}
+		n.types[typ.Key] = nt
+	}
+	return n
+}
+
+func (p normalizedProperty) value() map[string]interface{} {
+	return map[string]interface{}{"format": p.format, "tags": p.tags}
+}
+
+func (t normalizedType) value() map[string]interface{} {
+	return map[string]interface{}{"properties": t.properties, "templates": t.templates}
+}
+
+// DiffSchemas compares two schemas: types by key, property definitions by key and format,
+// the tag names of select properties and the template names of types. Names, colors,
+// layouts and icons are not compared.
+func DiffSchemas(from, to *Schema) *SchemaDiff {
+	d := &SchemaDiff{from: normalize(from), to: normalize(to)}
+
+	for _, key := range unionKeys(d.from.properties, d.to.properties) {
+		a, inFrom := d.from.properties[key]
+		b, inTo := d.to.properties[key]
+		path := "/properties/" + escapePointer(key)
+		switch {
+		case !inTo:
+			d.Patch = append(d.Patch, PatchOp{Op: "remove", Path: path})
+		case !inFrom:
+			d.Patch = append(d.Patch, PatchOp{Op: "add", Path: path, Value: b.value()})
+		default:
+			if a.format != b.format {
+				d.Patch = append(d.Patch, PatchOp{Op: "replace", Path: path + "/format", Value: b.format})
+			}
+			d.Patch = append(d.Patch, diffSet(path+"/tags", a.tags, b.tags)...)
+		}
+	}
+
+	for _, key := range unionKeys(d.from.types, d.to.types) {
+		a, inFrom := d.from.types[key]
+		b, inTo := d.to.types[key]
+		path := "/types/" + escapePointer(key)
+		switch {
+		case !inTo:
+			d.Patch = append(d.Patch, PatchOp{Op: "remove", Path: path})
+		case !inFrom:
+			d.Patch = append(d.Patch, PatchOp{Op: "add", Path: path, Value: b.value()})
+		default:
+			for _, prop := range unionKeys(a.properties, b.properties) {
+				format, inTo := b.properties[prop]
+				propPath := path + "/properties/" + escapePointer(prop)
+				if _, inFrom := a.properties[prop]; !inFrom {
+					d.Patch = append(d.Patch, PatchOp{Op: "add", Path: propPath, Value: format})
+				} else if !inTo {
+					d.Patch = append(d.Patch, PatchOp{Op: "remove", Path: propPath})
+				} else if a.properties[prop] != format {
+					d.Patch = append(d.Patch, PatchOp{Op: "replace", Path: propPath, Value: format})
+				}
+			}
+			d.Patch = append(d.Patch, diffSet(path+"/templates", a.templates, b.templates)...)
+		}
+	}
+
+	return d
+}
+
+// diffSet returns the operations that turn the set a into the set b
+func diffSet(path string, a, b map[string]bool) []PatchOp {
+	var ops []PatchOp
+	for _, name := range unionKeys(a, b) {
+		switch {
+		case !b[name]:
+			ops = append(ops, PatchOp{Op: "remove", Path: path + "/" + escapePointer(name)})
+		case !a[name]:
+			ops = append(ops, PatchOp{Op: "add", Path: path + "/" + escapePointer(name), Value: true})
+		}
+	}
+	return ops
+}
+
+// Empty reports whether the schemas are the same
+func (d *SchemaDiff) Empty() bool {
+	return len(d.Patch) == 0
+}
+
+// Unified renders the differences as a unified diff between fromName and toName. Only
+// the properties and types that differ are listed, with their unchanged header as context.
+func (d *SchemaDiff) Unified(fromName, toName string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+
+	for _, key := range unionKeys(d.from.properties, d.to.properties) {
+		a, inFrom := d.from.properties[key]
+		c, inTo := d.to.properties[key]
+		switch {
+		case !inTo:
+			fmt.Fprintf(&b, "-property %s (%s)\n", key, a.format)
+			writeSet(&b, "-  tag ", a.tags)
+		case !inFrom:
+			fmt.Fprintf(&b, "+property %s (%s)\n", key, c.format)
+			writeSet(&b, "+  tag ", c.tags)
+		case a.format != c.format:
+			fmt.Fprintf(&b, "-property %s (%s)\n+property %s (%s)\n", key, a.format, key, c.format)
+			writeSetDiff(&b, "  tag ", a.tags, c.tags)
+		case !maps.Equal(a.tags, c.tags):
+			fmt.Fprintf(&b, " property %s (%s)\n", key, a.format)
+			writeSetDiff(&b, "  tag ", a.tags, c.tags)
+		}
+	}
+
+	for _, key := range unionKeys(d.from.types, d.to.types) {
+		a, inFrom := d.from.types[key]
+		c, inTo := d.to.types[key]
+		switch {
+		case !inTo:
+			fmt.Fprintf(&b, "-type %s\n", key)
+			for _, prop := range slices.Sorted(maps.Keys(a.properties)) {
+				fmt.Fprintf(&b, "-  property %s (%s)\n", prop, a.properties[prop])
+			}
+			writeSet(&b, "-  template ", a.templates)
+		case !inFrom:
+			fmt.Fprintf(&b, "+type %s\n", key)
+			for _, prop := range slices.Sorted(maps.Keys(c.properties)) {
+				fmt.Fprintf(&b, "+  property %s (%s)\n", prop, c.properties[prop])
+			}
+			writeSet(&b, "+  template ", c.templates)
+		case !maps.Equal(a.properties, c.properties) || !maps.Equal(a.templates, c.templates):
+			fmt.Fprintf(&b, " type %s\n", key)
+			for _, prop := range unionKeys(a.properties, c.properties) {
+				fromFormat, inFrom := a.properties[prop]
+				toFormat, inTo := c.properties[prop]
+				if inFrom && (!inTo || fromFormat != toFormat) {
+					fmt.Fprintf(&b, "-  property %s (%s)\n", prop, fromFormat)
+				}
+				if inTo && (!inFrom || fromFormat != toFormat) {
+					fmt.Fprintf(&b, "+  property %s (%s)\n", prop, toFormat)
+				}
+			}
+			writeSetDiff(&b, "  template ", a.templates, c.templates)
+		}
+	}
+
+	return b.String()
+}
+
+// writeSet writes one prefixed line per element of a set, in order
+func writeSet(b *strings.Builder, prefix string, set map[string]bool) {
+	for _, name := range slices.Sorted(maps.Keys(set)) {
+		fmt.Fprintf(b, "%s%s\n", prefix, name)
+	}
+}
+
+// writeSetDiff writes the elements removed from and added to a set, in order
+func writeSetDiff(b *strings.Builder, label string, a, c map[string]bool) {
+	for _, name := range unionKeys(a, c) {
+		switch {
+		case !c[name]:
+			fmt.Fprintf(b, "-%s%s\n", label, name)
+		case !a[name]:
+			fmt.Fprintf(b, "+%s%s\n", label, name)
+		}
+	}
+}
+
+// unionKeys returns the keys of both maps, sorted
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := slices.Collect(maps.Keys(a))
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// escapePointer escapes a JSON Pointer (RFC 6901) reference token
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
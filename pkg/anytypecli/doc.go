@@ -0,0 +1,12 @@
+// Package anytypecli exposes the higher-level operations of anytype-cli as a Go
+// library: resolving spaces by name, iterating over paginated results, applying
+// an operation to many objects, and converting objects to and from markdown.
+//
+// The commands of anytype-cli are built on this package, so a program importing
+// it gets the same behavior without shelling out to the CLI. Every function takes
+// an anytype.Client, a Service for what the SDK lacks, or both; either may be the
+// real implementation or a test double.
+//
+// The package follows semantic versioning independently of the Anytype SDK, see
+// Version.
+package anytypecli
@@ -0,0 +1,172 @@
+package anytypecli
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/epheo/anytype-go"
+)
+
+// TagChange adds a tag to, or removes it from, a select or multi-select property
+type TagChange struct {
+	// Property is the property definition, without a value
+	Property anytype.Property
+	Tag      anytype.Tag
+}
+
+// ParseTagChange parses a tag change written as key=tag, the tag being given by name,
+// key or ID
+func ParseTagChange(ctx context.Context, a Service, spaceID, s string) (TagChange, error) {
+	assignment, err := ParseAssignment(s)
+	if err != nil || assignment.Value == "" {
+		return TagChange{}, fmt.Errorf("invalid tag %q, expected key=tag", s)
+	}
+	definition, err := ResolveProperty(ctx, a, spaceID, assignment.Key)
+	if err != nil {
+		return TagChange{}, err
+	}
+	property := anytype.Property{ID: definition.ID, Key: definition.Key, Name: definition.Name, Format: definition.Format}
+	if property.Format != "select" && property.Format != "multi_select" {
+		return TagChange{}, fmt.Errorf("property %s is a %s property, which has no tags", property.Key, property.Format)
+	}
+	tag, err := assignedTag(ctx, a, spaceID, property, assignment.Value)
+	if err != nil {
+		return TagChange{}, err
+	}
+	return TagChange{Property: property, Tag: *tag}, nil
+}
+
+// ObjectEdit is a set of changes applied in the same way to several objects
+type ObjectEdit struct {
+	// Set holds property values, as returned by AssignProperty
+	Set        []anytype.Property
+	AddTags    []TagChange
+	RemoveTags []TagChange
+	TypeKey    string
+	Archive    bool
+}
+
+// Describe returns the changes of the edit, one per line
+func (e ObjectEdit) Describe() []string {
+	var lines []string
+	for _, property := range e.Set {
+		if values, _ := propertyValues(anytype.Object{Properties: []anytype.Property{property}}, property.Key); len(values) > 0 {
+			lines = append(lines, fmt.Sprintf("set %s to %s", property.Key, strings.Join(values, ", ")))
+		} else {
+			lines = append(lines, fmt.Sprintf("clear %s", property.Key))
+		}
+	}
+	for _, change := range e.AddTags {
+		lines = append(lines, fmt.Sprintf("add tag %s to %s", change.Tag.Name, change.Property.Key))
+	}
+	for _, change := range e.RemoveTags {
+		lines = append(lines, fmt.Sprintf("remove tag %s from %s", change.Tag.Name, change.Property.Key))
+	}
+	if e.TypeKey != "" {
+		lines = append(lines, fmt.Sprintf("change type to %s", e.TypeKey))
+	}
+	if e.Archive {
+		lines = append(lines, "archive")
+	}
+	return lines
+}
+
+// Update returns the update that makes the changes of the edit, other than archiving, to
+// an object, and whether it changes anything. Tag changes start from the values of the
+// properties of obj, which must be loaded.
+func (e ObjectEdit) Update(obj anytype.Object) (UpdateObjectRequest, bool) {
+	properties := slices.Clone(obj.Properties)
+	for _, property := range e.Set {
+		properties = SetProperty(properties, property)
+	}
+	current := func(definition anytype.Property) anytype.Property {
+		for _, property := range properties {
+			if property.Key == definition.Key {
+				return property
+			}
+		}
+		return definition
+	}
+	for _, change := range e.AddTags {
+		property := current(change.Property)
+		if property.Format == "select" {
+			property.Select = &change.Tag
+		} else if !slices.ContainsFunc(property.MultiSelect, func(tag anytype.Tag) bool { return tag.ID == change.Tag.ID }) {
+			property.MultiSelect = append(slices.Clone(property.MultiSelect), change.Tag)
+		}
+		properties = SetProperty(properties, property)
+	}
+	for _, change := range e.RemoveTags {
+		property := current(change.Property)
+		if property.Format == "select" {
+			if property.Select != nil && property.Select.ID == change.Tag.ID {
+				property.Select = nil
+			}
+		} else {
+			property.MultiSelect = slices.DeleteFunc(slices.Clone(property.MultiSelect), func(tag anytype.Tag) bool { return tag.ID == change.Tag.ID })
+		}
+		properties = SetProperty(properties, property)
+	}
+
+	var req UpdateObjectRequest
+	for _, property := range properties {
+		before := slices.IndexFunc(obj.Properties, func(p anytype.Property) bool { return p.Key == property.Key })
+		var old map[string]interface{}
+		if before >= 0 {
+			old = PropertyValue(obj.Properties[before])
+		}
+		value := PropertyValue(property)
+		if reflect.DeepEqual(old, value) {
+			continue
+		}
+		if value == nil {
+			value = ClearedValue(property)
+		}
+		req.Properties = append(req.Properties, value)
+	}
+	if e.TypeKey != "" && e.TypeKey != obj.TypeKey {
+		req.TypeKey = e.TypeKey
+	}
+	return req, req.Properties != nil || req.TypeKey != ""
+}
+
+// ClearedValue returns the value that clears a property when updating an object
+func ClearedValue(property anytype.Property) map[string]interface{} {
+	var empty interface{}
+	switch property.Format {
+	case "multi_select", "objects", "files":
+		empty = []string{}
+	case "checkbox":
+		empty = false
+	}
+	return map[string]interface{}{"key": property.Key, property.Format: empty}
+}
+
+// Edit outcomes returned by ApplyEdit
+const (
+	EditUpdated   = "updated"
+	EditUnchanged = "unchanged"
+	EditArchived  = "archived"
+)
+
+// ApplyEdit makes the changes of an edit to an object, archiving it last, and returns
+// the outcome: EditUpdated, EditUnchanged or EditArchived
+func ApplyEdit(ctx context.Context, c anytype.Client, a Service, spaceID string, obj anytype.Object, e ObjectEdit) (string, error) {
+	outcome := EditUnchanged
+	if req, changed := e.Update(obj); changed {
+		if _, err := a.UpdateObject(ctx, spaceID, obj.ID, req); err != nil {
+			return "", err
+		}
+		outcome = EditUpdated
+	}
+	if e.Archive {
+		if _, err := c.Space(spaceID).Object(obj.ID).Delete(ctx); err != nil {
+			return "", err
+		}
+		outcome = EditArchived
+	}
+	return outcome, nil
+}
@@ -0,0 +1,335 @@
+package anytypecli
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/epheo/anytype-go"
+)
+
+// Filter conditions, as used by the filters of list views
+const (
+	ConditionEqual          = "eq"
+	ConditionNotEqual       = "ne"
+	ConditionGreater        = "gt"
+	ConditionGreaterOrEqual = "gte"
+	ConditionLess           = "lt"
+	ConditionLessOrEqual    = "lte"
+	ConditionContains       = "contains"
+	ConditionNotContains    = "ncontains"
+	ConditionEmpty          = "empty"
+	ConditionNotEmpty       = "nempty"
+)
+
+// Sort directions, as used by the sorts of list views
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// filterOperators maps the operators of filter expressions to conditions. Two-character
+// operators come first so that "<=" is not read as "<".
+var filterOperators = []struct{ op, condition string }{
+	{"!=", ConditionNotEqual},
+	{"<=", ConditionLessOrEqual},
+	{">=", ConditionGreaterOrEqual},
+	{"!~", ConditionNotContains},
+	{"=", ConditionEqual},
+	{"<", ConditionLess},
+	{">", ConditionGreater},
+	{"~", ConditionContains},
+}
+
+// emptyPattern matches the "key is empty" and "key is not empty" expressions
+var emptyPattern = regexp.MustCompile(`^\s*(\S+)\s+is\s+(not\s+)?empty\s*$`)
+
+// ParseFilter parses a filter expression: a property key, an operator and a value, as in
+// "status = Done", "due_date < today" or "name ~ report", or "key is [not] empty". The
+// operators are =, !=, <, <=, >, >=, ~ (contains) and !~ (does not contain). The value
+// may be quoted.
+func ParseFilter(expr string) (anytype.ListFilter, error) {
+	if match := emptyPattern.FindStringSubmatch(expr); match != nil {
+		condition := ConditionEmpty
+		if match[2] != "" {
+			condition = ConditionNotEmpty
+		}
+		return anytype.ListFilter{PropertyKey: match[1], Condition: condition}, nil
+	}
+
+	index, operator, condition := -1, "", ""
+	for _, candidate := range filterOperators {
+		if i := strings.Index(expr, candidate.op); i >= 0 && (index < 0 || i < index) {
+			index, operator, condition = i, candidate.op, candidate.condition
+		}
+	}
+	if index < 0 {
+		return anytype.ListFilter{}, fmt.Errorf("invalid filter '%s' (expected 'key operator value', e.g. 'status = Done')", expr)
+	}
+
+	key := strings.TrimSpace(expr[:index])
+	value := strings.TrimSpace(expr[index+len(operator):])
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	if key == "" || strings.ContainsAny(key, " \t") || value == "" {
+		return anytype.ListFilter{}, fmt.Errorf("invalid filter '%s' (expected 'key operator value', e.g. 'status = Done')", expr)
+	}
+	return anytype.ListFilter{PropertyKey: key, Condition: condition, Value: value}, nil
+}
+
+// FormatFilter writes a filter as an expression, see ParseFilter
+func FormatFilter(filter anytype.ListFilter) string {
+	switch filter.Condition {
+	case ConditionEmpty:
+		return filter.PropertyKey + " is empty"
+	case ConditionNotEmpty:
+		return filter.PropertyKey + " is not empty"
+	}
+	for _, candidate := range filterOperators {
+		if candidate.condition == filter.Condition {
+			return fmt.Sprintf("%s %s %s", filter.PropertyKey, candidate.op, filter.Value)
+		}
+	}
+	return fmt.Sprintf("%s %s %s", filter.PropertyKey, filter.Condition, filter.Value)
+}
+
+// ParseSort parses a sort written as "key", "key:asc" or "key:desc"
+func ParseSort(s string) (anytype.ListSort, error) {
+	key, direction, _ := strings.Cut(s, ":")
+	if direction == "" {
+		direction = SortAscending
+	}
+	if key == "" || (direction != SortAscending && direction != SortDescending) {
+		return anytype.ListSort{}, fmt.Errorf("invalid sort '%s' (expected key, key:asc or key:desc)", s)
+	}
+	return anytype.ListSort{PropertyKey: key, SortType: direction}, nil
+}
+
+// FormatSort writes a sort as "key asc" or "key desc"
+func FormatSort(sort anytype.ListSort) string {
+	return sort.PropertyKey + " " + sort.SortType
+}
+
+// MatchFilters reports whether an object satisfies every filter. Besides the keys of its
+// properties, "name" and "type" designate the name and the type key of the object.
+//
+// Select and multi_select properties compare by tag name, dates by day, and numbers
+// numerically; other values compare as case-insensitive text. A date value may also be
+// today, yesterday, tomorrow or an offset such as today+7d or today-2w, relative to now.
+func MatchFilters(obj anytype.Object, filters []anytype.ListFilter, now time.Time) bool {
+	for _, filter := range filters {
+		if !matchFilter(obj, filter, now) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchFilter(obj anytype.Object, filter anytype.ListFilter, now time.Time) bool {
+	values, format := propertyValues(obj, filter.PropertyKey)
+	switch filter.Condition {
+	case ConditionEmpty:
+		return len(values) == 0
+	case ConditionNotEmpty:
+		return len(values) > 0
+	case ConditionNotEqual:
+		return !slices.ContainsFunc(values, func(v string) bool { return compareValues(v, filter.Value, format, now) == 0 })
+	case ConditionNotContains:
+		return !slices.ContainsFunc(values, func(v string) bool { return containsFold(v, filter.Value) })
+	case ConditionContains:
+		return slices.ContainsFunc(values, func(v string) bool { return containsFold(v, filter.Value) })
+	}
+
+	return slices.ContainsFunc(values, func(v string) bool {
+		c := compareValues(v, filter.Value, format, now)
+		switch filter.Condition {
+		case ConditionEqual:
+			return c == 0
+		case ConditionGreater:
+			return c > 0
+		case ConditionGreaterOrEqual:
+			return c >= 0
+		case ConditionLess:
+			return c < 0
+		case ConditionLessOrEqual:
+			return c <= 0
+		}
+		return false
+	})
+}
+
+// SortObjects sorts objects by the sorts in turn, keeping their order otherwise. Objects
+// without a value for a sort key come last in both directions.
+func SortObjects(objects []anytype.Object, sorts []anytype.ListSort, now time.Time) {
+	slices.SortStableFunc(objects, func(a, b anytype.Object) int {
+		for _, sort := range sorts {
+			aValues, format := propertyValues(a, sort.PropertyKey)
+			bValues, _ := propertyValues(b, sort.PropertyKey)
+			switch {
+			case len(aValues) == 0 && len(bValues) == 0:
+				continue
+			case len(aValues) == 0:
+				return 1
+			case len(bValues) == 0:
+				return -1
+			}
+			c := compareValues(aValues[0], bValues[0], format, now)
+			if sort.SortType == SortDescending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+// propertyValues returns the non-empty values of a property of an object as text, and the
+// format of the property
+func propertyValues(obj anytype.Object, key string) ([]string, string) {
+	switch key {
+	case "name":
+		return nonEmpty(obj.Name), "text"
+	case "type":
+		return nonEmpty(obj.TypeKey), "text"
+	}
+
+	for _, property := range obj.Properties {
+		if property.Key != key {
+			continue
+		}
+		switch property.Format {
+		case "select":
+			if property.Select != nil {
+				return nonEmpty(property.Select.Name), property.Format
+			}
+			return nil, property.Format
+		case "multi_select":
+			var names []string
+			for _, tag := range property.MultiSelect {
+				names = append(names, tag.Name)
+			}
+			return names, property.Format
+		case "number":
+			return []string{strconv.FormatFloat(property.Number, 'f', -1, 64)}, property.Format
+		case "checkbox":
+			return []string{strconv.FormatBool(property.Checkbox)}, property.Format
+		case "date":
+			return nonEmpty(property.Date), property.Format
+		case "url":
+			return nonEmpty(property.URL), property.Format
+		case "email":
+			return nonEmpty(property.Email), property.Format
+		case "phone":
+			return nonEmpty(property.Phone), property.Format
+		case "files":
+			return property.Files, property.Format
+		case "objects":
+			return property.Objects, property.Format
+		default:
+			return nonEmpty(property.Text), property.Format
+		}
+	}
+	return nil, ""
+}
+
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+// compareValues compares a property value with another value of the same format
+func compareValues(a, b, format string, now time.Time) int {
+	switch format {
+	case "date":
+		// Against a timestamp, such as the cut-off of an age in hours, values compare to
+		// the second; otherwise dates compare by day
+		if bTime, err := time.Parse(time.RFC3339, b); err == nil {
+			if aTime, err := time.Parse(time.RFC3339, a); err == nil {
+				return aTime.Compare(bTime)
+			}
+		}
+		aDate, aOK := ParseDate(a, now)
+		bDate, bOK := ParseDate(b, now)
+		if aOK && bOK {
+			return aDate.Compare(bDate)
+		}
+	case "number":
+		aNumber, aErr := strconv.ParseFloat(a, 64)
+		bNumber, bErr := strconv.ParseFloat(b, 64)
+		if aErr == nil && bErr == nil {
+			return cmp.Compare(aNumber, bNumber)
+		}
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// agePattern matches the 30d and 2w forms of ages
+var agePattern = regexp.MustCompile(`^(\d+)([dw])$`)
+
+// ParseAge parses an age written in days or weeks, as 30d or 2w, or as a Go duration such
+// as 36h
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if match := agePattern.FindStringSubmatch(s); match != nil {
+		days, _ := strconv.Atoi(match[1])
+		if match[2] == "w" {
+			days *= 7
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	age, err := time.ParseDuration(s)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid age '%s' (expected e.g. 30d, 2w or 12h)", s)
+	}
+	return age, nil
+}
+
+// relativeDatePattern matches the today+7d and today-2w forms of dates
+var relativeDatePattern = regexp.MustCompile(`^today([+-])(\d+)([dw])$`)
+
+// ParseDate parses a date, written as 2006-01-02, as an RFC 3339 timestamp, or relative to
+// now as today, yesterday, tomorrow, today+7d or today-2w. The result is the start of the
+// day, in the location of now so that dates compare by day.
+func ParseDate(s string, now time.Time) (time.Time, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "today":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	}
+	if match := relativeDatePattern.FindStringSubmatch(strings.ToLower(s)); match != nil {
+		days, _ := strconv.Atoi(match[2])
+		if match[3] == "w" {
+			days *= 7
+		}
+		if match[1] == "-" {
+			days = -days
+		}
+		return today.AddDate(0, 0, days), true
+	}
+
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		// The day is the one written, whatever the time zone of the timestamp
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, now.Location()), true
+		}
+	}
+	return time.Time{}, false
+}
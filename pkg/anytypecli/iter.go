@@ -0,0 +1,119 @@
+package anytypecli
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+
+	"github.com/epheo/anytype-go"
+)
+
+// DefaultPageSize is the page size used when a page size of zero or less is given.
+// It is the default page size of the Anytype API.
+const DefaultPageSize = 100
+
+// page is the envelope of the paginated API responses
+type page[T any] struct {
+	Data       []T `json:"data"`
+	Pagination struct {
+		Total   int  `json:"total"`
+		Offset  int  `json:"offset"`
+		Limit   int  `json:"limit"`
+		HasMore bool `json:"has_more"`
+	} `json:"pagination"`
+}
+
+// Paginate iterates over every item of a paginated GET endpoint, requesting pageSize
+// items at a time. Iteration stops at the first error, which is yielded with a zero item.
+func Paginate[T any](ctx context.Context, a *API, apiPath string, pageSize int) iter.Seq2[T, error] {
+	return paginate[T](ctx, a, "GET", apiPath, nil, pageSize)
+}
+
+// paginate is Paginate for any method, sending body with every page request
+func paginate[T any](ctx context.Context, a *API, method, apiPath string, body interface{}, pageSize int) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		for offset := 0; ; {
+			query := url.Values{}
+			query.Set("offset", strconv.Itoa(offset))
+			query.Set("limit", strconv.Itoa(pageSize))
+
+			var resp page[T]
+			if err := a.Do(ctx, method, apiPath, query, body, &resp); err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range resp.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !resp.Pagination.HasMore || len(resp.Data) == 0 {
+				return
+			}
+			offset += len(resp.Data)
+		}
+	}
+}
+
+// Collect drains an iterator into a slice, stopping at the first error
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	items := []T{}
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Spaces iterates over every space the app key has access to
+func (a *API) Spaces(ctx context.Context) iter.Seq2[anytype.Space, error] {
+	return Paginate[anytype.Space](ctx, a, "/spaces", DefaultPageSize)
+}
+
+// Objects iterates over every object of a space
+func (a *API) Objects(ctx context.Context, spaceID string) iter.Seq2[anytype.Object, error] {
+	return Paginate[anytype.Object](ctx, a, fmt.Sprintf("/spaces/%s/objects", spaceID), DefaultPageSize)
+}
+
+// Types iterates over every object type of a space
+func (a *API) Types(ctx context.Context, spaceID string) iter.Seq2[anytype.Type, error] {
+	return Paginate[anytype.Type](ctx, a, fmt.Sprintf("/spaces/%s/types", spaceID), DefaultPageSize)
+}
+
+// Members iterates over every member of a space
+func (a *API) Members(ctx context.Context, spaceID string) iter.Seq2[anytype.Member, error] {
+	return Paginate[anytype.Member](ctx, a, fmt.Sprintf("/spaces/%s/members", spaceID), DefaultPageSize)
+}
+
+// ListObjects iterates over the objects of a list, as shown by one of its views
+func (a *API) ListObjects(ctx context.Context, spaceID, listID, viewID string) iter.Seq2[anytype.Object, error] {
+	return Paginate[anytype.Object](ctx, a, fmt.Sprintf("/spaces/%s/lists/%s/views/%s/objects", spaceID, listID, viewID), DefaultPageSize)
+}
+
+// CollectionObjects iterates over the objects of a list, regardless of its views
+func (a *API) CollectionObjects(ctx context.Context, spaceID, listID string) iter.Seq2[anytype.Object, error] {
+	return Paginate[anytype.Object](ctx, a, fmt.Sprintf("/spaces/%s/lists/%s/objects", spaceID, listID), DefaultPageSize)
+}
+
+// Search iterates over every result of a search in a space, or in all spaces if spaceID is empty
+func (a *API) Search(ctx context.Context, spaceID string, req anytype.SearchRequest) iter.Seq2[anytype.Object, error] {
+	apiPath := "/search"
+	if spaceID != "" {
+		apiPath = fmt.Sprintf("/spaces/%s/search", spaceID)
+	}
+	return paginate[anytype.Object](ctx, a, "POST", apiPath, req, DefaultPageSize)
+}
+
+// AllObjects returns every object of a space, following pagination
+func AllObjects(ctx context.Context, a Service, spaceID string) ([]anytype.Object, error) {
+	return Collect(a.Objects(ctx, spaceID))
+}
@@ -0,0 +1,154 @@
+package anytypecli
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/epheo/anytype-go"
+)
+
+// Lists are objects of a collection or a set type. A collection holds the objects added
+// to it; a set shows the objects of its source types, given by SetSourceProperty.
+const (
+	ListKindCollection = "collection"
+	ListKindSet        = "set"
+
+	CollectionTypeKey = "ot-collection"
+	SetTypeKey        = "ot-set"
+	SetSourceProperty = "set_of"
+)
+
+// ListKinds are the kinds of list that can be created
+var ListKinds = []string{ListKindCollection, ListKindSet}
+
+// ListKind returns the kind of a list object, or "" if the object is not a list
+func ListKind(obj anytype.Object) string {
+	if slices.Contains(ListKinds, obj.Layout) {
+		return obj.Layout
+	}
+	return ""
+}
+
+// CreateListRequest holds the content of a new list
+type CreateListRequest struct {
+	Name string
+	// Kind is one of ListKinds
+	Kind string
+	// SourceTypes are the keys of the types whose objects a set shows
+	SourceTypes []string
+	Icon        *anytype.Icon
+}
+
+// CreateList creates a collection, or a set of the objects of req.SourceTypes
+func (a *API) CreateList(ctx context.Context, spaceID string, req CreateListRequest) (*anytype.Object, error) {
+	body := map[string]interface{}{"name": req.Name}
+	switch req.Kind {
+	case ListKindCollection:
+		if len(req.SourceTypes) > 0 {
+			return nil, fmt.Errorf("a collection has no source types, objects are added to it")
+		}
+		body["type_key"] = CollectionTypeKey
+	case ListKindSet:
+		if len(req.SourceTypes) == 0 {
+			return nil, fmt.Errorf("a set needs at least one source type")
+		}
+		body["type_key"] = SetTypeKey
+		body["properties"] = []map[string]interface{}{
+			{"key": SetSourceProperty, "objects": req.SourceTypes},
+		}
+	default:
+		return nil, fmt.Errorf("invalid list kind '%s' (expected one of: %s)", req.Kind, strings.Join(ListKinds, ", "))
+	}
+	if req.Icon != nil {
+		body["icon"] = req.Icon
+	}
+
+	var resp objectResponse
+	if err := a.Do(ctx, "POST", fmt.Sprintf("/spaces/%s/objects", spaceID), nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Object, nil
+}
+
+// Lists iterates over the collections and sets of a space, leaving out archived ones
+func (a *API) Lists(ctx context.Context, spaceID string) iter.Seq2[anytype.Object, error] {
+	return func(yield func(anytype.Object, error) bool) {
+		for obj, err := range a.Objects(ctx, spaceID) {
+			if err != nil {
+				yield(anytype.Object{}, err)
+				return
+			}
+			if ListKind(obj) == "" || obj.Archived {
+				continue
+			}
+			if !yield(obj, nil) {
+				return
+			}
+		}
+	}
+}
+
+// CollectionsOf returns the collections of a space that hold an object
+func CollectionsOf(ctx context.Context, a Service, spaceID, objectID string) ([]anytype.Object, error) {
+	var collections []anytype.Object
+	for list, err := range a.Lists(ctx, spaceID) {
+		if err != nil {
+			return nil, err
+		}
+		if ListKind(list) != ListKindCollection {
+			continue
+		}
+		for obj, err := range a.CollectionObjects(ctx, spaceID, list.ID) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to list the objects of %s: %w", list.Name, err)
+			}
+			if obj.ID == objectID {
+				collections = append(collections, list)
+				break
+			}
+		}
+	}
+	return collections, nil
+}
+
+// ResolveList takes either a list ID or a list name and returns the ID of the
+// corresponding list, with the same matching rules as ResolveSpace
+func ResolveList(ctx context.Context, a Service, spaceID, idOrName string) (string, error) {
+	lists, err := Collect(a.Lists(ctx, spaceID))
+	if err != nil {
+		return "", fmt.Errorf("failed to list lists: %w", err)
+	}
+
+	candidates := make([]Match, 0, len(lists))
+	for _, list := range lists {
+		candidates = append(candidates, Match{ID: list.ID, Name: list.Name})
+	}
+	return resolve("list", candidates, idOrName)
+}
+
+// ResolveView takes either a view ID or a view name and returns the corresponding view of
+// a list, with the same matching rules as ResolveSpace
+func ResolveView(ctx context.Context, c anytype.Client, spaceID, listID, idOrName string) (*anytype.ListView, error) {
+	resp, err := c.Space(spaceID).List(listID).Views().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list views: %w", err)
+	}
+
+	candidates := make([]Match, 0, len(resp.Data))
+	for _, view := range resp.Data {
+		candidates = append(candidates, Match{ID: view.ID, Name: view.Name})
+	}
+	viewID, err := resolve("view", candidates, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	for _, view := range resp.Data {
+		if view.ID == viewID {
+			return &view, nil
+		}
+	}
+	return nil, &StatusError{Status: 404, Body: fmt.Sprintf("the list has no view '%s'", idOrName)}
+}
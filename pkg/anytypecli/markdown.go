@@ -0,0 +1,87 @@
+package anytypecli
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/epheo/anytype-go"
+)
+
+// DefaultTypeKey is the object type used to import markdown when none is given
+const DefaultTypeKey = "ot-page"
+
+// ErrNoName is returned by ImportMarkdown when neither the markdown nor the options name the object
+var ErrNoName = errors.New("the markdown has no '# ' title and no name was given")
+
+// ExportMarkdown returns the markdown representation of an object
+func ExportMarkdown(ctx context.Context, c anytype.Client, spaceID, objectID string) (string, error) {
+	resp, err := c.Space(spaceID).Object(objectID).Export(ctx, "markdown")
+	if err != nil {
+		return "", err
+	}
+	return resp.Markdown, nil
+}
+
+// MarkdownDocument is a markdown text split into the object name and body
+type MarkdownDocument struct {
+	Name string
+	Body string
+}
+
+// ParseMarkdown splits a markdown text into an object name and body. A level 1
+// heading on the first non-blank line gives the name and is removed from the body;
+// otherwise the name is empty and the body is the whole text.
+func ParseMarkdown(markdown string) MarkdownDocument {
+	text := strings.TrimLeft(markdown, "\r\n\t ")
+	firstLine, rest, _ := strings.Cut(text, "\n")
+	if title, ok := strings.CutPrefix(strings.TrimRight(firstLine, "\r"), "# "); ok {
+		return MarkdownDocument{
+			Name: strings.TrimSpace(title),
+			Body: strings.TrimLeft(rest, "\r\n"),
+		}
+	}
+	return MarkdownDocument{Body: markdown}
+}
+
+// ImportOptions configures ImportMarkdown
+type ImportOptions struct {
+	// Name of the object, overriding the heading of the markdown text
+	Name string
+	// TypeKey of the object, DefaultTypeKey if empty
+	TypeKey string
+	// TemplateID to create the object from, if any
+	TemplateID string
+	// Icon is an optional emoji icon
+	Icon string
+}
+
+// ImportMarkdown creates an object in a space from a markdown text, see ParseMarkdown
+func ImportMarkdown(ctx context.Context, c anytype.Client, spaceID, markdown string, opts ImportOptions) (*anytype.Object, error) {
+	doc := ParseMarkdown(markdown)
+	if opts.Name != "" {
+		doc.Name = opts.Name
+	}
+	if doc.Name == "" {
+		return nil, ErrNoName
+	}
+
+	req := anytype.CreateObjectRequest{
+		TypeKey:    opts.TypeKey,
+		Name:       doc.Name,
+		Body:       doc.Body,
+		TemplateID: opts.TemplateID,
+	}
+	if req.TypeKey == "" {
+		req.TypeKey = DefaultTypeKey
+	}
+	if opts.Icon != "" {
+		req.Icon = &anytype.Icon{Format: anytype.IconFormatEmoji, Emoji: opts.Icon}
+	}
+
+	resp, err := c.Space(spaceID).Objects().Create(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Object, nil
+}
@@ -0,0 +1,140 @@
+package anytypecli
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/epheo/anytype-go"
+)
+
+// MemberRoles are the roles of space members, from the least to the most privileged
+var MemberRoles = []string{
+	string(anytype.MemberRoleViewer),
+	string(anytype.MemberRoleEditor),
+	string(anytype.MemberRoleOwner),
+}
+
+// CompareRoles compares two member roles by privilege, unknown roles ranking lowest
+func CompareRoles(a, b string) int {
+	return slices.Index(MemberRoles, a) - slices.Index(MemberRoles, b)
+}
+
+// UpdateMemberRequest changes the status or the role of a member. Setting the status
+// of a joining member to active approves its request, to declined declines it; setting
+// it to removed removes an active member from the space.
+type UpdateMemberRequest struct {
+	Status string `json:"status,omitempty"`
+	Role   string `json:"role,omitempty"`
+}
+
+// memberResponse is the body of the member endpoints
+type memberResponse struct {
+	Member anytype.Member `json:"member"`
+}
+
+// UpdateMember changes the status or the role of a member of a space
+func (a *API) UpdateMember(ctx context.Context, spaceID, memberID string, req UpdateMemberRequest) (*anytype.Member, error) {
+	var resp memberResponse
+	if err := a.Do(ctx, "PATCH", fmt.Sprintf("/spaces/%s/members/%s", spaceID, memberID), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Member, nil
+}
+
+// ResolveMember takes a member ID, identity, global name or name and returns the
+// corresponding member of a space. IDs, identities and global names must match exactly;
+// names follow the matching rules of ResolveSpace.
+func ResolveMember(ctx context.Context, c anytype.Client, spaceID, idOrName string) (*anytype.Member, error) {
+	resp, err := c.Space(spaceID).Members().List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	candidates := make([]Match, 0, len(resp.Data))
+	for _, member := range resp.Data {
+		if member.Identity == idOrName || strings.EqualFold(member.GlobalName, idOrName) {
+			return &member, nil
+		}
+		candidates = append(candidates, Match{ID: member.ID, Name: member.Name})
+	}
+	memberID, err := resolve("member", candidates, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range resp.Data {
+		if member.ID == memberID {
+			return &member, nil
+		}
+	}
+	return nil, &StatusError{Status: 404, Body: fmt.Sprintf("the space has no member '%s'", idOrName)}
+}
+
+// CheckLastOwner returns an error if member is the last active owner of the space and
+// would no longer be one, with the new role newRole or, if newRole is empty, once removed
+func CheckLastOwner(members []anytype.Member, member anytype.Member, newRole string) error {
+	if member.Role != string(anytype.MemberRoleOwner) || newRole == member.Role {
+		return nil
+	}
+	for _, other := range members {
+		if other.ID != member.ID && other.Role == member.Role && other.Status == string(anytype.MemberStatusActive) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is the last owner of the space, make another member owner first", member.Name)
+}
+
+// MemberStatuses are the statuses of space members
+var MemberStatuses = []string{
+	string(anytype.MemberStatusJoining),
+	string(anytype.MemberStatusActive),
+	string(anytype.MemberStatusRemoved),
+	string(anytype.MemberStatusDeclined),
+	string(anytype.MemberStatusRemoving),
+	string(anytype.MemberStatusCanceled),
+}
+
+// SpaceMember is a member of a space, as listed across spaces
+type SpaceMember struct {
+	SpaceID        string `json:"space_id" yaml:"space_id"`
+	SpaceName      string `json:"space_name" yaml:"space_name"`
+	anytype.Member `yaml:",inline"`
+}
+
+// MemberFilter selects members by role, status and identity; empty fields match every member
+type MemberFilter struct {
+	Role   string
+	Status string
+	// Identity matches the identity or, case-insensitively, the global name of a member
+	Identity string
+}
+
+// Match reports whether a member satisfies the filter
+func (mf MemberFilter) Match(member anytype.Member) bool {
+	return (mf.Role == "" || member.Role == mf.Role) &&
+		(mf.Status == "" || member.Status == mf.Status) &&
+		(mf.Identity == "" || member.Identity == mf.Identity || strings.EqualFold(member.GlobalName, mf.Identity))
+}
+
+// AllMembers iterates over the members of every space, space by space
+func AllMembers(ctx context.Context, a Service) iter.Seq2[SpaceMember, error] {
+	return func(yield func(SpaceMember, error) bool) {
+		for space, err := range a.Spaces(ctx) {
+			if err != nil {
+				yield(SpaceMember{}, err)
+				return
+			}
+			for member, err := range a.Members(ctx, space.ID) {
+				if err != nil {
+					yield(SpaceMember{}, fmt.Errorf("space %s: %w", space.Name, err))
+					return
+				}
+				if !yield(SpaceMember{SpaceID: space.ID, SpaceName: space.Name, Member: member}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
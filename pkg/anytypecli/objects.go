@@ -0,0 +1,224 @@
+package anytypecli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/epheo/anytype-go"
+)
+
+// ReadOnlyPropertyKeys are the keys of the properties Anytype maintains itself, which are
+// not set when creating or updating an object
+var ReadOnlyPropertyKeys = []string{
+	"name", "type", "creator", CreatedDateProperty, "last_modified_by", LastModifiedDateProperty,
+	"last_opened_date", "added_date", "links", "backlinks", SetSourceProperty, TargetTypeProperty,
+}
+
+// CreateObjectRequest holds the content of a new object. Properties are written as
+// returned by PropertyValue.
+type CreateObjectRequest struct {
+	TypeKey    string                   `json:"type_key"`
+	Name       string                   `json:"name"`
+	Body       string                   `json:"body,omitempty"`
+	Icon       *anytype.Icon            `json:"icon,omitempty"`
+	TemplateID string                   `json:"template_id,omitempty"`
+	Properties []map[string]interface{} `json:"properties,omitempty"`
+}
+
+// CreateObject creates an object in a space
+func (a *API) CreateObject(ctx context.Context, spaceID string, req CreateObjectRequest) (*anytype.Object, error) {
+	var resp objectResponse
+	if err := a.Do(ctx, "POST", fmt.Sprintf("/spaces/%s/objects", spaceID), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Object, nil
+}
+
+// PropertyValue returns the value of a property in the form the API takes when creating
+// or updating an object, select values being given by tag ID. It returns nil for the
+// properties that have no value or are in ReadOnlyPropertyKeys.
+func PropertyValue(property anytype.Property) map[string]interface{} {
+	if slices.Contains(ReadOnlyPropertyKeys, property.Key) {
+		return nil
+	}
+	var value interface{}
+	switch property.Format {
+	case "select":
+		if property.Select != nil {
+			value = property.Select.ID
+		}
+	case "multi_select":
+		var ids []string
+		for _, tag := range property.MultiSelect {
+			ids = append(ids, tag.ID)
+		}
+		if len(ids) > 0 {
+			value = ids
+		}
+	case "number":
+		value = property.Number
+	case "checkbox":
+		value = property.Checkbox
+	case "date":
+		value = nonEmptyValue(property.Date)
+	case "url":
+		value = nonEmptyValue(property.URL)
+	case "email":
+		value = nonEmptyValue(property.Email)
+	case "phone":
+		value = nonEmptyValue(property.Phone)
+	case "files":
+		if len(property.Files) > 0 {
+			value = property.Files
+		}
+	case "objects":
+		if len(property.Objects) > 0 {
+			value = property.Objects
+		}
+	default:
+		value = nonEmptyValue(property.Text)
+	}
+	if value == nil {
+		return nil
+	}
+	return map[string]interface{}{"key": property.Key, property.Format: value}
+}
+
+// PropertyValues returns the values of the properties that PropertyValue gives one for
+func PropertyValues(properties []anytype.Property) []map[string]interface{} {
+	var values []map[string]interface{}
+	for _, property := range properties {
+		if value := PropertyValue(property); value != nil {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// nonEmptyValue returns s, or nil if s is empty
+func nonEmptyValue(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// Assignment sets a property to a value written as text, as given by key=value on the
+// command line
+type Assignment struct {
+	// Key is the key, ID or name of the property
+	Key   string
+	Value string
+}
+
+// ParseAssignment parses a property assignment written as key=value
+func ParseAssignment(s string) (Assignment, error) {
+	key, value, ok := strings.Cut(s, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return Assignment{}, fmt.Errorf("invalid property value %q, expected key=value", s)
+	}
+	return Assignment{Key: key, Value: strings.TrimSpace(value)}, nil
+}
+
+// AssignProperty returns the property of a space that an assignment designates, with the
+// value it gives parsed according to the format of the property. Checkboxes take true or
+// false and dates are read by ParseDate; select values are tag names, keys or IDs, and
+// multi-select and object values are comma-separated. An empty value clears the property.
+func AssignProperty(ctx context.Context, a Service, spaceID string, assignment Assignment, now time.Time) (anytype.Property, error) {
+	definition, err := ResolveProperty(ctx, a, spaceID, assignment.Key)
+	if err != nil {
+		return anytype.Property{}, err
+	}
+	property := anytype.Property{ID: definition.ID, Key: definition.Key, Name: definition.Name, Format: definition.Format}
+	if slices.Contains(ReadOnlyPropertyKeys, property.Key) {
+		return property, fmt.Errorf("property %s is maintained by Anytype and cannot be set", property.Key)
+	}
+	value := assignment.Value
+	if value == "" {
+		return property, nil
+	}
+
+	switch property.Format {
+	case "select":
+		tag, err := assignedTag(ctx, a, spaceID, property, value)
+		if err != nil {
+			return property, err
+		}
+		property.Select = tag
+	case "multi_select":
+		for _, name := range splitList(value) {
+			tag, err := assignedTag(ctx, a, spaceID, property, name)
+			if err != nil {
+				return property, err
+			}
+			property.MultiSelect = append(property.MultiSelect, *tag)
+		}
+	case "number":
+		if property.Number, err = strconv.ParseFloat(value, 64); err != nil {
+			return property, fmt.Errorf("invalid number %q for property %s", value, property.Key)
+		}
+	case "checkbox":
+		if property.Checkbox, err = strconv.ParseBool(value); err != nil {
+			return property, fmt.Errorf("invalid checkbox value %q for property %s, expected true or false", value, property.Key)
+		}
+	case "date":
+		date, ok := ParseDate(value, now)
+		if !ok {
+			return property, fmt.Errorf("invalid date %q for property %s", value, property.Key)
+		}
+		property.Date = date.Format("2006-01-02") + "T00:00:00Z"
+	case "url":
+		property.URL = value
+	case "email":
+		property.Email = value
+	case "phone":
+		property.Phone = value
+	case "objects":
+		property.Objects = splitList(value)
+	case "files":
+		return property, fmt.Errorf("property %s holds files, which cannot be set from text", property.Key)
+	default:
+		property.Text = value
+	}
+	return property, nil
+}
+
+// assignedTag resolves a tag of an assigned select or multi-select property
+func assignedTag(ctx context.Context, a Service, spaceID string, property anytype.Property, ref string) (*anytype.Tag, error) {
+	tag, err := ResolveTag(ctx, a, spaceID, property.ID, ref)
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.Status == 404 {
+		return nil, &StatusError{Status: 404, Body: fmt.Sprintf("property %s has no tag '%s'", property.Key, ref)}
+	}
+	return tag, err
+}
+
+// SetProperty returns properties with property in place of the one with the same key,
+// or added at the end
+func SetProperty(properties []anytype.Property, property anytype.Property) []anytype.Property {
+	properties = slices.Clone(properties)
+	for i := range properties {
+		if properties[i].Key == property.Key {
+			properties[i] = property
+			return properties
+		}
+	}
+	return append(properties, property)
+}
+
+// splitList splits a comma-separated list, dropping blank items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,228 @@
+package anytypecli
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/epheo/anytype-go"
+)
+
+// Property is a property definition of a space, as returned by the properties endpoints
+type Property struct {
+	ID     string `json:"id" yaml:"id"`
+	Key    string `json:"key" yaml:"key"`
+	Name   string `json:"name" yaml:"name"`
+	Format string `json:"format" yaml:"format"`
+}
+
+// TagColors are the colors a tag can have
+var TagColors = []string{"grey", "yellow", "orange", "red", "pink", "purple", "blue", "ice", "teal", "lime"}
+
+// DefaultTagColor is the color of tags created without one
+const DefaultTagColor = "grey"
+
+// ValidTagColor reports whether color is one of TagColors
+func ValidTagColor(color string) bool {
+	return slices.Contains(TagColors, color)
+}
+
+// HasTags reports whether properties of the given format take their values from tags
+func HasTags(format string) bool {
+	return format == "select" || format == "multi_select"
+}
+
+// CreatePropertyRequest holds the definition of a new property
+type CreatePropertyRequest struct {
+	Key    string             `json:"key,omitempty"`
+	Name   string             `json:"name"`
+	Format string             `json:"format"`
+	Tags   []CreateTagRequest `json:"tags,omitempty"`
+}
+
+// UpdatePropertyRequest holds the changes to a property. Empty fields are left unchanged;
+// the format of a property cannot be changed.
+type UpdatePropertyRequest struct {
+	Key  string `json:"key,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// CreateTagRequest holds the definition of a new tag
+type CreateTagRequest struct {
+	Key   string `json:"key,omitempty"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// UpdateTagRequest holds the changes to a tag. Empty fields are left unchanged.
+type UpdateTagRequest struct {
+	Name  string `json:"name,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// ParseTag parses a tag written as "Name" or "Name:color". The color defaults to DefaultTagColor.
+func ParseTag(s string) (CreateTagRequest, error) {
+	name, color, found := strings.Cut(s, ":")
+	if !found {
+		color = DefaultTagColor
+	}
+	if name == "" {
+		return CreateTagRequest{}, fmt.Errorf("invalid tag '%s' (expected Name or Name:color)", s)
+	}
+	if !ValidTagColor(color) {
+		return CreateTagRequest{}, fmt.Errorf("invalid color '%s' for tag '%s' (expected one of: %s)",
+			color, name, strings.Join(TagColors, ", "))
+	}
+	return CreateTagRequest{Name: name, Color: color}, nil
+}
+
+// propertyResponse is the envelope of the property endpoints
+type propertyResponse struct {
+	Property Property `json:"property"`
+}
+
+// tagResponse is the envelope of the tag endpoints
+type tagResponse struct {
+	Tag anytype.Tag `json:"tag"`
+}
+
+// Properties iterates over every property definition of a space
+func (a *API) Properties(ctx context.Context, spaceID string) iter.Seq2[Property, error] {
+	return Paginate[Property](ctx, a, fmt.Sprintf("/spaces/%s/properties", spaceID), DefaultPageSize)
+}
+
+// GetProperty returns a property definition
+func (a *API) GetProperty(ctx context.Context, spaceID, propertyID string) (*Property, error) {
+	var resp propertyResponse
+	if err := a.Do(ctx, "GET", fmt.Sprintf("/spaces/%s/properties/%s", spaceID, propertyID), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Property, nil
+}
+
+// CreateProperty creates a property definition in a space
+func (a *API) CreateProperty(ctx context.Context, spaceID string, req CreatePropertyRequest) (*Property, error) {
+	var resp propertyResponse
+	if err := a.Do(ctx, "POST", fmt.Sprintf("/spaces/%s/properties", spaceID), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Property, nil
+}
+
+// UpdateProperty applies the changes of req to a property definition
+func (a *API) UpdateProperty(ctx context.Context, spaceID, propertyID string, req UpdatePropertyRequest) (*Property, error) {
+	var resp propertyResponse
+	if err := a.Do(ctx, "PATCH", fmt.Sprintf("/spaces/%s/properties/%s", spaceID, propertyID), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Property, nil
+}
+
+// DeleteProperty deletes a property definition and returns it
+func (a *API) DeleteProperty(ctx context.Context, spaceID, propertyID string) (*Property, error) {
+	var resp propertyResponse
+	if err := a.Do(ctx, "DELETE", fmt.Sprintf("/spaces/%s/properties/%s", spaceID, propertyID), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Property, nil
+}
+
+// Tags iterates over the tags of a select or multi-select property
+func (a *API) Tags(ctx context.Context, spaceID, propertyID string) iter.Seq2[anytype.Tag, error] {
+	return Paginate[anytype.Tag](ctx, a, fmt.Sprintf("/spaces/%s/properties/%s/tags", spaceID, propertyID), DefaultPageSize)
+}
+
+// GetTag returns a tag of a select or multi-select property
+func (a *API) GetTag(ctx context.Context, spaceID, propertyID, tagID string) (*anytype.Tag, error) {
+	var resp tagResponse
+	if err := a.Do(ctx, "GET", fmt.Sprintf("/spaces/%s/properties/%s/tags/%s", spaceID, propertyID, tagID), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Tag, nil
+}
+
+// CreateTag adds a tag to a select or multi-select property
+func (a *API) CreateTag(ctx context.Context, spaceID, propertyID string, req CreateTagRequest) (*anytype.Tag, error) {
+	var resp tagResponse
+	if err := a.Do(ctx, "POST", fmt.Sprintf("/spaces/%s/properties/%s/tags", spaceID, propertyID), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Tag, nil
+}
+
+// UpdateTag applies the changes of req to a tag
+func (a *API) UpdateTag(ctx context.Context, spaceID, propertyID, tagID string, req UpdateTagRequest) (*anytype.Tag, error) {
+	var resp tagResponse
+	if err := a.Do(ctx, "PATCH", fmt.Sprintf("/spaces/%s/properties/%s/tags/%s", spaceID, propertyID, tagID), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Tag, nil
+}
+
+// DeleteTag removes a tag from a property and returns it
+func (a *API) DeleteTag(ctx context.Context, spaceID, propertyID, tagID string) (*anytype.Tag, error) {
+	var resp tagResponse
+	if err := a.Do(ctx, "DELETE", fmt.Sprintf("/spaces/%s/properties/%s/tags/%s", spaceID, propertyID, tagID), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Tag, nil
+}
+
+// ResolveProperty takes a property ID, key or name and returns the corresponding property.
+// Keys are matched first, then IDs and names with the same rules as ResolveSpace; a
+// reference that matches nothing is looked up as an ID so that the API reports it.
+func ResolveProperty(ctx context.Context, a Service, spaceID, ref string) (*Property, error) {
+	properties, err := Collect(a.Properties(ctx, spaceID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list properties: %w", err)
+	}
+
+	candidates := make([]Match, 0, len(properties))
+	for _, property := range properties {
+		if property.Key == ref {
+			return &property, nil
+		}
+		candidates = append(candidates, Match{ID: property.ID, Name: property.Name})
+	}
+
+	id, err := resolve("property", candidates, ref)
+	if err != nil {
+		return nil, err
+	}
+	for _, property := range properties {
+		if property.ID == id {
+			return &property, nil
+		}
+	}
+	return a.GetProperty(ctx, spaceID, id)
+}
+
+// ResolveTag takes a tag ID, key or name and returns the corresponding tag of a property,
+// with the same matching rules as ResolveProperty
+func ResolveTag(ctx context.Context, a Service, spaceID, propertyID, ref string) (*anytype.Tag, error) {
+	tags, err := Collect(a.Tags(ctx, spaceID, propertyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	candidates := make([]Match, 0, len(tags))
+	for _, tag := range tags {
+		if tag.Key == ref {
+			return &tag, nil
+		}
+		candidates = append(candidates, Match{ID: tag.ID, Name: tag.Name})
+	}
+
+	id, err := resolve("tag", candidates, ref)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		if tag.ID == id {
+			return &tag, nil
+		}
+	}
+	return a.GetTag(ctx, spaceID, propertyID, id)
+}
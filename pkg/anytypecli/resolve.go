@@ -0,0 +1,83 @@
+package anytypecli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/epheo/anytype-go"
+)
+
+// Match is a candidate returned by a name lookup
+type Match struct {
+	ID   string
+	Name string
+}
+
+// AmbiguousNameError is returned when a name matches more than one resource
+type AmbiguousNameError struct {
+	// Resource is the kind of resource looked up, e.g. "space"
+	Resource string
+	// Name is the name that was looked up
+	Name string
+	// Matches lists every resource whose name matched
+	Matches []Match
+}
+
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("%d %ss match '%s'", len(e.Matches), e.Resource, e.Name)
+}
+
+// ResolveSpace takes either a space ID or space name and returns the corresponding space ID.
+//
+// The input is matched, in order, against the space IDs, the space names (case-insensitive)
+// and then as a substring of the space names. A substring matching several spaces returns an
+// *AmbiguousNameError. An input that matches nothing is returned unchanged, so that the API
+// reports unknown IDs itself.
+func ResolveSpace(ctx context.Context, c anytype.Client, spaceIdOrName string) (string, error) {
+	resp, err := c.Spaces().List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list spaces: %w", err)
+	}
+
+	candidates := make([]Match, 0, len(resp.Data))
+	for _, space := range resp.Data {
+		candidates = append(candidates, Match{ID: space.ID, Name: space.Name})
+	}
+	return resolve("space", candidates, spaceIdOrName)
+}
+
+// resolve picks the candidate designated by idOrName, see ResolveSpace
+func resolve(resource string, candidates []Match, idOrName string) (string, error) {
+	// First: Check if the input directly matches an ID
+	for _, candidate := range candidates {
+		if candidate.ID == idOrName {
+			return candidate.ID, nil
+		}
+	}
+
+	// Second: Try to find an exact case-insensitive name match
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate.Name, idOrName) {
+			return candidate.ID, nil
+		}
+	}
+
+	// Third: Collect partial name matches
+	var matches []Match
+	for _, candidate := range candidates {
+		if strings.Contains(strings.ToLower(candidate.Name), strings.ToLower(idOrName)) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		// Fourth: As a fallback, treat the input as a direct ID and let the API handle it
+		return idOrName, nil
+	case 1:
+		return matches[0].ID, nil
+	default:
+		return "", &AmbiguousNameError{Resource: resource, Name: idOrName, Matches: matches}
+	}
+}
@@ -0,0 +1,39 @@
+package anytypecli
+
+import (
+	"context"
+	"slices"
+
+	"github.com/epheo/anytype-go"
+)
+
+// RestoredFromProperty is the key of the text property in which an object recreated from an
+// archived object records the ID of that object
+const RestoredFromProperty = "restored_from"
+
+// MarkRestored returns obj with its RestoredFromProperty set to its own ID, so that a copy of
+// it made by a Copier records the archived object it was recreated from. The Copier must be
+// allowed to create the property if the space lacks it.
+func MarkRestored(obj anytype.Object) anytype.Object {
+	properties := slices.DeleteFunc(slices.Clone(obj.Properties), func(p anytype.Property) bool {
+		return p.Key == RestoredFromProperty
+	})
+	obj.Properties = append(properties, anytype.Property{Key: RestoredFromProperty, Name: "Restored from", Format: "text", Text: obj.ID})
+	return obj
+}
+
+// RestoredCopies returns the IDs of the live objects of a space recreated from an archived
+// object, keyed by the ID of the archived object
+func RestoredCopies(ctx context.Context, a Service, spaceID string) (map[string][]string, error) {
+	objects, err := AllObjects(ctx, a, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	copies := map[string][]string{}
+	for _, obj := range objects {
+		if from := PropertyText(obj, RestoredFromProperty); from != "" && !obj.Archived {
+			copies[from] = append(copies[from], obj.ID)
+		}
+	}
+	return copies, nil
+}
@@ -0,0 +1,568 @@
+package anytypecli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/epheo/anytype-go"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the version of the schema file format written by ExportSchema
+const SchemaVersion = 1
+
+// Schema describes the object types and property definitions of a space
+type Schema struct {
+	Version    int              `json:"version" yaml:"version"`
+	Properties []SchemaProperty `json:"properties" yaml:"properties"`
+	Types      []SchemaType     `json:"types" yaml:"types"`
+}
+
+// SchemaProperty is a property definition with its tags
+type SchemaProperty struct {
+	Key    string      `json:"key" yaml:"key"`
+	Name   string      `json:"name" yaml:"name"`
+	Format string      `json:"format" yaml:"format"`
+	Tags   []SchemaTag `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// SchemaTag is a tag of a select or multi-select property, identified by its name
+type SchemaTag struct {
+	Name  string `json:"name" yaml:"name"`
+	Color string `json:"color" yaml:"color"`
+}
+
+// SchemaType is an object type with its property definitions and templates
+type SchemaType struct {
+	Key        string                       `json:"key" yaml:"key"`
+	Name       string                       `json:"name" yaml:"name"`
+	Layout     string                       `json:"layout" yaml:"layout"`
+	Icon       string                       `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Properties []anytype.PropertyDefinition `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Templates  []SchemaTemplate             `json:"templates,omitempty" yaml:"templates,omitempty"`
+}
+
+// SchemaTemplate is a template of a type, identified by its name, with its markdown body
+type SchemaTemplate struct {
+	Name string `json:"name" yaml:"name"`
+	Icon string `json:"icon,omitempty" yaml:"icon,omitempty"`
+	Body string `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// BuiltinTypeKeys are the keys of the object types Anytype creates in every space.
+// Keys are also recognized with the legacy "ot-" prefix.
+var BuiltinTypeKeys = []string{
+	"page", "note", "task", "bookmark", "collection", "set", "project", "human", "profile",
+	"file", "image", "video", "audio", "template", "date", "chat", "participant", "goal",
+}
+
+// BuiltinPropertyKeys are the keys of the property definitions Anytype creates in every space
+var BuiltinPropertyKeys = []string{
+	"name", "description", "type", "tag", "status", "done", "due_date", "creator",
+	"created_date", "last_modified_by", "last_modified_date", "last_opened_date",
+	"added_date", "links", "backlinks", "source", "url", "email", "phone",
+}
+
+// IsBuiltinType reports whether key is the key of a built-in object type
+func IsBuiltinType(key string) bool {
+	return slices.Contains(BuiltinTypeKeys, strings.TrimPrefix(key, "ot-"))
+}
+
+// IsBuiltinProperty reports whether key is the key of a built-in property definition
+func IsBuiltinProperty(key string) bool {
+	return slices.Contains(BuiltinPropertyKeys, key)
+}
+
+// ExportSchemaOptions controls what ExportSchema includes
+type ExportSchemaOptions struct {
+	// All includes the built-in types and properties, which are left out by default
+	All bool
+}
+
+// schemaIndex maps the keys and names of an exported schema to the IDs the API needs
+type schemaIndex struct {
+	properties map[string]string            // property key -> property ID
+	tags       map[string]map[string]string // property key -> tag name -> tag ID
+	templates  map[string]map[string]string // type key -> template name -> template ID
+}
+
+// ExportSchema returns the object types, property definitions, tags and templates of a space
+func ExportSchema(ctx context.Context, c anytype.Client, a Service, spaceID string, opts ExportSchemaOptions) (*Schema, error) {
+	schema, _, err := exportSchema(ctx, c, a, spaceID, opts)
+	return schema, err
+}
+
+// exportSchema is ExportSchema, also returning the IDs of the exported properties and tags
+func exportSchema(ctx context.Context, c anytype.Client, a Service, spaceID string, opts ExportSchemaOptions) (*Schema, *schemaIndex, error) {
+	schema := &Schema{Version: SchemaVersion, Properties: []SchemaProperty{}, Types: []SchemaType{}}
+	index := &schemaIndex{properties: map[string]string{}, tags: map[string]map[string]string{}, templates: map[string]map[string]string{}}
+
+	properties, err := Collect(a.Properties(ctx, spaceID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list properties: %w", err)
+	}
+	for _, property := range properties {
+		if !opts.All && IsBuiltinProperty(property.Key) {
+			continue
+		}
+		sp := SchemaProperty{Key: property.Key, Name: property.Name, Format: property.Format}
+		index.properties[property.Key] = property.ID
+		if HasTags(property.Format) {
+			tags, err := Collect(a.Tags(ctx, spaceID, property.ID))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list tags of property %s: %w", property.Key, err)
+			}
+			index.tags[property.Key] = map[string]string{}
+			for _, tag := range tags {
+				sp.Tags = append(sp.Tags, SchemaTag{Name: tag.Name, Color: tag.Color})
+				index.tags[property.Key][tag.Name] = tag.ID
+			}
+		}
+		schema.Properties = append(schema.Properties, sp)
+	}
+
+	types, err := c.Space(spaceID).Types().List(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list types: %w", err)
+	}
+	for _, listed := range types {
+		if !opts.All && IsBuiltinType(listed.Key) {
+			continue
+		}
+		resp, err := c.Space(spaceID).Type(listed.Key).Get(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get type %s: %w", listed.Key, err)
+		}
+		typ := resp.Type
+		st := SchemaType{Key: typ.Key, Name: typ.Name, Layout: typ.Layout, Properties: typ.PropertyDefinitions}
+		if typ.Icon != nil && typ.Icon.Format == anytype.IconFormatEmoji {
+			st.Icon = typ.Icon.Emoji
+		}
+
+		templates, err := c.Space(spaceID).Type(typ.Key).Templates().List(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list templates of type %s: %w", typ.Key, err)
+		}
+		index.templates[typ.Key] = map[string]string{}
+		for _, template := range templates {
+			if template.Archived {
+				continue
+			}
+			body, err := ExportMarkdown(ctx, c, spaceID, template.ID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to export template %s of type %s: %w", template.Name, typ.Key, err)
+			}
+			sTemplate := SchemaTemplate{Name: template.Name, Body: body}
+			if template.Icon != nil && template.Icon.Format == anytype.IconFormatEmoji {
+				sTemplate.Icon = template.Icon.Emoji
+			}
+			st.Templates = append(st.Templates, sTemplate)
+			index.templates[typ.Key][template.Name] = template.ID
+		}
+		schema.Types = append(schema.Types, st)
+	}
+
+	return schema, index, nil
+}
+
+// ReadSchema decodes a schema written as YAML or JSON and checks that it is consistent
+func ReadSchema(r io.Reader) (*Schema, error) {
+	var schema Schema
+	if err := yaml.NewDecoder(r).Decode(&schema); err != nil {
+		if err == io.EOF {
+			return nil, fmt.Errorf("empty schema")
+		}
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// Validate checks the version of the schema, that every property and type has a key, that
+// every template has a name, and that formats and tags are valid
+func (s *Schema) Validate() error {
+	if s.Version != SchemaVersion {
+		return fmt.Errorf("unsupported schema version %d (expected %d)", s.Version, SchemaVersion)
+	}
+	seen := map[string]bool{}
+	for _, property := range s.Properties {
+		if property.Key == "" || property.Name == "" {
+			return fmt.Errorf("every property needs a key and a name")
+		}
+		if seen[property.Key] {
+			return fmt.Errorf("property %s is defined twice", property.Key)
+		}
+		seen[property.Key] = true
+		if !ValidPropertyFormat(property.Format) {
+			return fmt.Errorf("invalid format '%s' for property %s (expected one of: %s)",
+				property.Format, property.Key, strings.Join(PropertyFormats, ", "))
+		}
+		if len(property.Tags) > 0 && !HasTags(property.Format) {
+			return fmt.Errorf("property %s has tags, but only select and multi_select properties can have tags", property.Key)
+		}
+		for _, tag := range property.Tags {
+			if tag.Name == "" || !ValidTagColor(tag.Color) {
+				return fmt.Errorf("invalid tag '%s:%s' for property %s", tag.Name, tag.Color, property.Key)
+			}
+		}
+	}
+	seen = map[string]bool{}
+	for _, typ := range s.Types {
+		if typ.Key == "" || typ.Name == "" || typ.Layout == "" {
+			return fmt.Errorf("every type needs a key, a name and a layout")
+		}
+		if seen[typ.Key] {
+			return fmt.Errorf("type %s is defined twice", typ.Key)
+		}
+		seen[typ.Key] = true
+		for _, def := range typ.Properties {
+			if !ValidPropertyFormat(def.Format) {
+				return fmt.Errorf("invalid format '%s' for property %s of type %s", def.Format, def.Key, typ.Key)
+			}
+		}
+		templates := map[string]bool{}
+		for _, template := range typ.Templates {
+			if template.Name == "" {
+				return fmt.Errorf("every template of type %s needs a name", typ.Key)
+			}
+			if templates[template.Name] {
+				return fmt.Errorf("template %s of type %s is defined twice", template.Name, typ.Key)
+			}
+			templates[template.Name] = true
+		}
+	}
+	return nil
+}
+
+// Schema change actions
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionNoop   = "no-op"
+	// ActionManual marks a change the API cannot make, such as changing the format
+	// of a property
+	ActionManual = "manual"
+)
+
+// SchemaChange is one step of a SchemaPlan
+type SchemaChange struct {
+	// Action is one of ActionCreate, ActionUpdate, ActionNoop or ActionManual
+	Action string `json:"action" yaml:"action"`
+	// Resource is "property", "tag", "type" or "template"
+	Resource string `json:"resource" yaml:"resource"`
+	// Key is the key of a property or type, or the name of a tag or template
+	Key string `json:"key" yaml:"key"`
+	// Parent is the key of the property of a tag, or of the type of a template
+	Parent string `json:"parent,omitempty" yaml:"parent,omitempty"`
+	// Details lists the differences, one per line
+	Details []string `json:"details,omitempty" yaml:"details,omitempty"`
+
+	apply func(ctx context.Context) error
+}
+
+// SchemaPlan is the list of changes that bring a space in line with a schema. Resources of
+// the space that the schema does not mention are never deleted.
+type SchemaPlan struct {
+	Changes []SchemaChange `json:"changes" yaml:"changes"`
+}
+
+// Count returns the number of changes with the given action
+func (p *SchemaPlan) Count(action string) int {
+	n := 0
+	for _, change := range p.Changes {
+		if change.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// CountResource returns the number of changes with the given action on the given resource
+func (p *SchemaPlan) CountResource(action, resource string) int {
+	n := 0
+	for _, change := range p.Changes {
+		if change.Action == action && change.Resource == resource {
+			n++
+		}
+	}
+	return n
+}
+
+// HasChanges reports whether applying the plan would change the space
+func (p *SchemaPlan) HasChanges() bool {
+	return p.Count(ActionCreate)+p.Count(ActionUpdate) > 0
+}
+
+// Apply makes the create and update changes of the plan in order, stopping at the first
+// failure. It returns the number of changes made.
+func (p *SchemaPlan) Apply(ctx context.Context) (int, error) {
+	applied := 0
+	for _, change := range p.Changes {
+		if change.apply == nil {
+			continue
+		}
+		if err := change.apply(ctx); err != nil {
+			return applied, fmt.Errorf("failed to %s %s %s: %w", change.Action, change.Resource, change.Key, err)
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+// PlanSchema compares a schema with the live state of a space and returns the changes that
+// applying it would make
+func PlanSchema(ctx context.Context, c anytype.Client, a Service, spaceID string, desired *Schema) (*SchemaPlan, error) {
+	live, index, err := exportSchema(ctx, c, a, spaceID, ExportSchemaOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+	planner := &schemaPlanner{c: c, a: a, spaceID: spaceID, index: index}
+	return &SchemaPlan{Changes: planner.diff(desired, live)}, nil
+}
+
+// schemaPlanner computes schema changes and binds them to the API calls that make them
+type schemaPlanner struct {
+	c       anytype.Client
+	a       Service
+	spaceID string
+	index   *schemaIndex
+}
+
+// diff returns the changes that turn live into desired, without deletions
+func (p *schemaPlanner) diff(desired, live *Schema) []SchemaChange {
+	var changes []SchemaChange
+	for _, property := range desired.Properties {
+		changes = append(changes, p.diffProperty(property, findProperty(live, property.Key))...)
+	}
+	for _, typ := range desired.Types {
+		changes = append(changes, p.diffType(typ, findType(live, typ.Key))...)
+	}
+	return changes
+}
+
+// diffProperty compares a property and its tags with the live property, nil if missing
+func (p *schemaPlanner) diffProperty(desired SchemaProperty, live *SchemaProperty) []SchemaChange {
+	if live == nil {
+		change := SchemaChange{Action: ActionCreate, Resource: "property", Key: desired.Key,
+			Details: []string{fmt.Sprintf("name: %q", desired.Name), fmt.Sprintf("format: %s", desired.Format)}}
+		req := CreatePropertyRequest{Key: desired.Key, Name: desired.Name, Format: desired.Format}
+		for _, tag := range desired.Tags {
+			change.Details = append(change.Details, fmt.Sprintf("+ tag %q (%s)", tag.Name, tag.Color))
+			req.Tags = append(req.Tags, CreateTagRequest{Name: tag.Name, Color: tag.Color})
+		}
+		change.apply = func(ctx context.Context) error {
+			_, err := p.a.CreateProperty(ctx, p.spaceID, req)
+			return err
+		}
+		return []SchemaChange{change}
+	}
+
+	propertyID := p.index.properties[live.Key]
+	change := SchemaChange{Action: ActionNoop, Resource: "property", Key: desired.Key}
+	if desired.Format != live.Format {
+		change.Action = ActionManual
+		change.Details = append(change.Details, fmt.Sprintf("format: %s -> %s (the format of a property cannot be changed)", live.Format, desired.Format))
+	} else if desired.Name != live.Name {
+		change.Action = ActionUpdate
+		change.Details = append(change.Details, fmt.Sprintf("name: %q -> %q", live.Name, desired.Name))
+		req := UpdatePropertyRequest{Name: desired.Name}
+		change.apply = func(ctx context.Context) error {
+			_, err := p.a.UpdateProperty(ctx, p.spaceID, propertyID, req)
+			return err
+		}
+	}
+	changes := []SchemaChange{change}
+
+	for _, tag := range desired.Tags {
+		tagChange := SchemaChange{Action: ActionNoop, Resource: "tag", Key: tag.Name, Parent: desired.Key}
+		i := slices.IndexFunc(live.Tags, func(t SchemaTag) bool { return t.Name == tag.Name })
+		switch {
+		case i < 0:
+			tagChange.Action = ActionCreate
+			tagChange.Details = []string{fmt.Sprintf("color: %s", tag.Color)}
+			req := CreateTagRequest{Name: tag.Name, Color: tag.Color}
+			tagChange.apply = func(ctx context.Context) error {
+				_, err := p.a.CreateTag(ctx, p.spaceID, propertyID, req)
+				return err
+			}
+		case live.Tags[i].Color != tag.Color:
+			tagChange.Action = ActionUpdate
+			tagChange.Details = []string{fmt.Sprintf("color: %s -> %s", live.Tags[i].Color, tag.Color)}
+			tagID := p.index.tags[live.Key][tag.Name]
+			req := UpdateTagRequest{Color: tag.Color}
+			tagChange.apply = func(ctx context.Context) error {
+				_, err := p.a.UpdateTag(ctx, p.spaceID, propertyID, tagID, req)
+				return err
+			}
+		}
+		changes = append(changes, tagChange)
+	}
+	return changes
+}
+
+// diffType compares a type and its templates with the live type, nil if missing
+func (p *schemaPlanner) diffType(desired SchemaType, live *SchemaType) []SchemaChange {
+	var change SchemaChange
+	if live == nil {
+		change = SchemaChange{Action: ActionCreate, Resource: "type", Key: desired.Key,
+			Details: []string{fmt.Sprintf("name: %q", desired.Name), fmt.Sprintf("layout: %s", desired.Layout)}}
+		if desired.Icon != "" {
+			change.Details = append(change.Details, fmt.Sprintf("icon: %s", desired.Icon))
+		}
+		for _, def := range desired.Properties {
+			change.Details = append(change.Details, fmt.Sprintf("+ property %s (%s)", def.Key, def.Format))
+		}
+		req := anytype.CreateTypeRequest{
+			Key:        desired.Key,
+			Name:       desired.Name,
+			Layout:     desired.Layout,
+			Icon:       schemaIcon(desired.Icon),
+			Properties: desired.Properties,
+		}
+		change.apply = func(ctx context.Context) error {
+			_, err := p.c.Space(p.spaceID).Types().Create(ctx, req)
+			return err
+		}
+	} else {
+		change = SchemaChange{Action: ActionNoop, Resource: "type", Key: desired.Key}
+		req := UpdateTypeRequest{}
+		if desired.Name != live.Name {
+			change.Details = append(change.Details, fmt.Sprintf("name: %q -> %q", live.Name, desired.Name))
+			req.Name = desired.Name
+		}
+		if desired.Layout != live.Layout {
+			change.Details = append(change.Details, fmt.Sprintf("layout: %s -> %s", live.Layout, desired.Layout))
+			req.Layout = desired.Layout
+		}
+		if desired.Icon != "" && desired.Icon != live.Icon {
+			change.Details = append(change.Details, fmt.Sprintf("icon: %s -> %s", live.Icon, desired.Icon))
+			req.Icon = schemaIcon(desired.Icon)
+		}
+
+		var add []anytype.PropertyDefinition
+		for _, def := range desired.Properties {
+			i := slices.IndexFunc(live.Properties, func(d anytype.PropertyDefinition) bool { return d.Key == def.Key })
+			switch {
+			case i < 0:
+				change.Details = append(change.Details, fmt.Sprintf("+ property %s (%s)", def.Key, def.Format))
+				add = append(add, def)
+			case live.Properties[i] != def:
+				change.Details = append(change.Details, fmt.Sprintf("~ property %s: %s (%s) -> %s (%s)",
+					def.Key, live.Properties[i].Name, live.Properties[i].Format, def.Name, def.Format))
+				add = append(add, def)
+			}
+		}
+		if len(add) > 0 {
+			// Definitions of the live type that the schema leaves out are kept
+			merged, _ := MergePropertyDefinitions(live.Properties, add, nil)
+			req.Properties = &merged
+		}
+
+		if len(change.Details) > 0 {
+			change.Action = ActionUpdate
+			change.apply = func(ctx context.Context) error {
+				_, err := p.a.UpdateType(ctx, p.spaceID, desired.Key, req)
+				return err
+			}
+		}
+	}
+	changes := []SchemaChange{change}
+
+	for _, template := range desired.Templates {
+		var liveTemplate *SchemaTemplate
+		if live != nil {
+			liveTemplate = findTemplate(live, template.Name)
+		}
+		changes = append(changes, p.diffTemplate(desired.Key, template, liveTemplate))
+	}
+	return changes
+}
+
+// diffTemplate compares a template of a type with the live template, nil if missing. The
+// body and icon are only compared when the schema gives them.
+func (p *schemaPlanner) diffTemplate(typeKey string, desired SchemaTemplate, live *SchemaTemplate) SchemaChange {
+	change := SchemaChange{Action: ActionNoop, Resource: "template", Key: desired.Name, Parent: typeKey}
+	if live == nil {
+		change.Action = ActionCreate
+		if desired.Icon != "" {
+			change.Details = append(change.Details, fmt.Sprintf("icon: %s", desired.Icon))
+		}
+		change.Details = append(change.Details, fmt.Sprintf("body: %d lines", countLines(desired.Body)))
+		req := CreateTemplateRequest{Name: desired.Name, Body: desired.Body, Icon: schemaIcon(desired.Icon)}
+		change.apply = func(ctx context.Context) error {
+			_, err := p.a.CreateTemplate(ctx, p.spaceID, typeKey, req)
+			return err
+		}
+		return change
+	}
+
+	req := UpdateObjectRequest{}
+	if desired.Icon != "" && desired.Icon != live.Icon {
+		change.Details = append(change.Details, fmt.Sprintf("icon: %s -> %s", live.Icon, desired.Icon))
+		req.Icon = schemaIcon(desired.Icon)
+	}
+	if desired.Body != "" && desired.Body != live.Body {
+		change.Details = append(change.Details, fmt.Sprintf("body: %d -> %d lines", countLines(live.Body), countLines(desired.Body)))
+		req.Markdown = desired.Body
+	}
+	if len(change.Details) > 0 {
+		change.Action = ActionUpdate
+		templateID := p.index.templates[typeKey][live.Name]
+		change.apply = func(ctx context.Context) error {
+			_, err := p.a.UpdateObject(ctx, p.spaceID, templateID, req)
+			return err
+		}
+	}
+	return change
+}
+
+// findProperty returns the property of the schema with the given key, or nil
+func findProperty(s *Schema, key string) *SchemaProperty {
+	for i := range s.Properties {
+		if s.Properties[i].Key == key {
+			return &s.Properties[i]
+		}
+	}
+	return nil
+}
+
+// findType returns the type of the schema with the given key, or nil
+func findType(s *Schema, key string) *SchemaType {
+	for i := range s.Types {
+		if s.Types[i].Key == key {
+			return &s.Types[i]
+		}
+	}
+	return nil
+}
+
+// findTemplate returns the template of a schema type with the given name, or nil
+func findTemplate(t *SchemaType, name string) *SchemaTemplate {
+	for i := range t.Templates {
+		if t.Templates[i].Name == name {
+			return &t.Templates[i]
+		}
+	}
+	return nil
+}
+
+// countLines returns the number of lines of a text, ignoring a final newline
+func countLines(text string) int {
+	if text == "" {
+		return 0
+	}
+	return strings.Count(strings.TrimSuffix(text, "\n"), "\n") + 1
+}
+
+// schemaIcon returns an emoji icon, or nil if emoji is empty
+func schemaIcon(emoji string) *anytype.Icon {
+	if emoji == "" {
+		return nil
+	}
+	return &anytype.Icon{Format: anytype.IconFormatEmoji, Emoji: emoji}
+}
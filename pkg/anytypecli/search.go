@@ -0,0 +1,195 @@
+package anytypecli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/epheo/anytype-go"
+)
+
+// searchSortProperties are the sort keys the search endpoint accepts
+var searchSortProperties = []anytype.SortProperty{
+	anytype.SortPropertyCreatedDate, anytype.SortPropertyLastModifiedDate, anytype.SortPropertyLastOpenedDate, anytype.SortPropertyName,
+}
+
+// SearchQuery is a search with the filters and sorts of the search endpoint and those it
+// lacks. Request returns the part the API evaluates; Match and Sort evaluate the rest over
+// the properties of the results.
+type SearchQuery struct {
+	Query string
+	// Types are type keys, an object matching any of them
+	Types []string
+	// Filters are matched as by MatchFilters
+	Filters []anytype.ListFilter
+	// Sorts apply in turn; the API sorts by the first if it can
+	Sorts []anytype.ListSort
+	// Layouts are layouts, an object matching any of them
+	Layouts []string
+	// Tags are tag names, an object matching if every one is set on one of its select or
+	// multi_select properties
+	Tags []string
+	// Archived searches the archived objects, which the API does not search, instead
+	Archived bool
+}
+
+// Request returns the search request of the part of q the API evaluates. A filter on the
+// type with = is sent as the types of the request when q has none, so its value must be a
+// type key: SearchObjects resolves type names first.
+func (q SearchQuery) Request() anytype.SearchRequest {
+	req := anytype.SearchRequest{Query: q.Query, Types: q.Types}
+	if len(req.Types) == 0 {
+		for _, filter := range q.Filters {
+			if filter.PropertyKey == "type" && filter.Condition == ConditionEqual {
+				req.Types = []string{filter.Value}
+				break
+			}
+		}
+	}
+	if len(q.Sorts) > 0 && slices.Contains(searchSortProperties, anytype.SortProperty(q.Sorts[0].PropertyKey)) {
+		req.Sort = &anytype.SortOptions{
+			Property:  anytype.SortProperty(q.Sorts[0].PropertyKey),
+			Direction: anytype.SortDirection(q.Sorts[0].SortType),
+		}
+	}
+	return req
+}
+
+// Match reports whether an object satisfies the conditions of q that Request leaves out
+func (q SearchQuery) Match(obj anytype.Object, now time.Time) bool {
+	if len(q.Layouts) > 0 && !slices.Contains(q.Layouts, obj.Layout) {
+		return false
+	}
+	for _, tag := range q.Tags {
+		if !HasTag(obj, tag) {
+			return false
+		}
+	}
+	return MatchFilters(obj, q.Filters, now)
+}
+
+// Sort sorts search results by the sorts of q, unless the API has sorted them by its only one
+func (q SearchQuery) Sort(objects []anytype.Object, now time.Time) {
+	if len(q.Sorts) == 1 && q.Request().Sort != nil && !q.Archived {
+		return
+	}
+	SortObjects(objects, q.Sorts, now)
+}
+
+// HasTag reports whether a select or multi_select property of an object has a tag named
+// name, ignoring case
+func HasTag(obj anytype.Object, name string) bool {
+	for _, property := range obj.Properties {
+		if property.Format != "select" && property.Format != "multi_select" {
+			continue
+		}
+		values, _ := propertyValues(obj, property.Key)
+		if slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, name) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchObjects runs a search in a space, or in all spaces if spaceID is empty, evaluating
+// what the API cannot of q over the results. Type names in the filters on the type are
+// resolved to type keys first. Archived objects are listed space by space and matched by
+// name against the query, as their bodies are not searched.
+func SearchObjects(ctx context.Context, a Service, spaceID string, q SearchQuery, now time.Time) ([]anytype.Object, error) {
+	q, err := resolveTypeFilters(ctx, a, spaceID, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []anytype.Object
+	if q.Archived {
+		spaceIDs := []string{spaceID}
+		if spaceID == "" {
+			spaces, err := Collect(a.Spaces(ctx))
+			if err != nil {
+				return nil, fmt.Errorf("failed to list spaces: %w", err)
+			}
+			spaceIDs = spaceIDs[:0]
+			for _, space := range spaces {
+				spaceIDs = append(spaceIDs, space.ID)
+			}
+		}
+		for _, id := range spaceIDs {
+			archived, err := a.ArchivedObjects(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			for _, obj := range archived {
+				if containsFold(obj.Name, q.Query) && (len(q.Types) == 0 || slices.Contains(q.Types, obj.TypeKey)) {
+					objects = append(objects, obj)
+				}
+			}
+		}
+	} else {
+		if objects, err = Collect(a.Search(ctx, spaceID, q.Request())); err != nil {
+			return nil, err
+		}
+	}
+
+	objects = slices.DeleteFunc(objects, func(obj anytype.Object) bool { return !q.Match(obj, now) })
+	q.Sort(objects, now)
+	return objects, nil
+}
+
+// resolveTypeFilters returns q with the values of its filters on the type that are the
+// name of a type, ignoring case, replaced by the key of that type. The types are those of
+// the space, or of every space if spaceID is empty. Values that are a key, or neither a
+// key nor a name, are kept. A name given to types with different keys is ambiguous.
+func resolveTypeFilters(ctx context.Context, a Service, spaceID string, q SearchQuery) (SearchQuery, error) {
+	if !slices.ContainsFunc(q.Filters, func(f anytype.ListFilter) bool { return f.PropertyKey == "type" }) {
+		return q, nil
+	}
+
+	spaceIDs := []string{spaceID}
+	if spaceID == "" {
+		spaces, err := Collect(a.Spaces(ctx))
+		if err != nil {
+			return q, fmt.Errorf("failed to list spaces: %w", err)
+		}
+		spaceIDs = spaceIDs[:0]
+		for _, space := range spaces {
+			spaceIDs = append(spaceIDs, space.ID)
+		}
+	}
+	var types []Match
+	for _, id := range spaceIDs {
+		spaceTypes, err := Collect(a.Types(ctx, id))
+		if err != nil {
+			return q, fmt.Errorf("failed to list types: %w", err)
+		}
+		for _, typ := range spaceTypes {
+			if match := (Match{ID: typ.Key, Name: typ.Name}); !slices.Contains(types, match) {
+				types = append(types, match)
+			}
+		}
+	}
+
+	q.Filters = slices.Clone(q.Filters)
+	for i, filter := range q.Filters {
+		if filter.PropertyKey != "type" || slices.ContainsFunc(types, func(m Match) bool { return m.ID == filter.Value }) {
+			continue
+		}
+		var keys []Match
+		for _, typ := range types {
+			if strings.EqualFold(typ.Name, filter.Value) && !slices.ContainsFunc(keys, func(m Match) bool { return m.ID == typ.ID }) {
+				keys = append(keys, typ)
+			}
+		}
+		switch len(keys) {
+		case 0:
+			// Neither a key nor a name, the filter matches no object
+		case 1:
+			q.Filters[i].Value = keys[0].ID
+		default:
+			return q, &AmbiguousNameError{Resource: "type", Name: filter.Value, Matches: keys}
+		}
+	}
+	return q, nil
+}
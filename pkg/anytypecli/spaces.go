@@ -0,0 +1,43 @@
+package anytypecli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/epheo/anytype-go"
+)
+
+// UpdateSpaceRequest holds the fields of a space to change; nil fields are left as they are
+type UpdateSpaceRequest struct {
+	Name        *string       `json:"name,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	Icon        *anytype.Icon `json:"icon,omitempty"`
+}
+
+// spaceResponse is the body of the space endpoints
+type spaceResponse struct {
+	Space anytype.Space `json:"space"`
+}
+
+// UpdateSpace changes the name, description or icon of a space
+func (a *API) UpdateSpace(ctx context.Context, spaceID string, req UpdateSpaceRequest) (*anytype.Space, error) {
+	var resp spaceResponse
+	if err := a.Do(ctx, "PATCH", fmt.Sprintf("/spaces/%s", spaceID), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Space, nil
+}
+
+// CreateSpace creates a space. The SDK encodes the body of this request twice, which the
+// API rejects.
+func (a *API) CreateSpace(ctx context.Context, req anytype.CreateSpaceRequest) (*anytype.Space, error) {
+	body := map[string]interface{}{"name": req.Name, "description": req.Description}
+	if req.Icon != nil {
+		body["icon"] = req.Icon
+	}
+	var resp spaceResponse
+	if err := a.Do(ctx, "POST", "/spaces", nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Space, nil
+}
@@ -0,0 +1,129 @@
+package anytypecli
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/epheo/anytype-go"
+)
+
+// Properties holding the creation and last modification dates of objects
+const (
+	CreatedDateProperty      = "created_date"
+	LastModifiedDateProperty = "last_modified_date"
+)
+
+// Count is the number of objects or members sharing a value
+type Count struct {
+	Name  string `json:"name" yaml:"name"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+// WeekActivity is the number of objects created and modified during an ISO week
+type WeekActivity struct {
+	// Week is written as 2006-W01
+	Week     string `json:"week" yaml:"week"`
+	Created  int    `json:"created" yaml:"created"`
+	Modified int    `json:"modified" yaml:"modified"`
+}
+
+// ObjectSize is the size of the markdown body of an object
+type ObjectSize struct {
+	ID    string `json:"id" yaml:"id"`
+	Name  string `json:"name" yaml:"name"`
+	Bytes int    `json:"bytes" yaml:"bytes"`
+}
+
+// SpaceStats summarizes the contents of a space. Counts by type and layout, lists,
+// untitled objects and activity cover the objects that are not archived.
+type SpaceStats struct {
+	Objects       int            `json:"objects" yaml:"objects"`
+	Archived      int            `json:"archived" yaml:"archived"`
+	Lists         int            `json:"lists" yaml:"lists"`
+	ByType        []Count        `json:"by_type" yaml:"by_type"`
+	ByLayout      []Count        `json:"by_layout" yaml:"by_layout"`
+	MembersByRole []Count        `json:"members_by_role" yaml:"members_by_role"`
+	Weeks         []WeekActivity `json:"weeks" yaml:"weeks"`
+	// Largest is empty if body sizes were not computed
+	Largest  []ObjectSize     `json:"largest" yaml:"largest"`
+	Untitled []anytype.Object `json:"untitled" yaml:"untitled"`
+}
+
+// ComputeStats summarizes the objects and the active members of a space. Activity covers
+// the weeks ISO weeks up to now, from the created_date and last_modified_date properties.
+// bodySizes, keyed by object ID, gives the top largest objects; it may be nil.
+func ComputeStats(objects []anytype.Object, members []anytype.Member, bodySizes map[string]int, top int, now time.Time, weeks int) SpaceStats {
+	stats := SpaceStats{Largest: []ObjectSize{}, Untitled: []anytype.Object{}}
+	byType, byLayout, byRole := map[string]int{}, map[string]int{}, map[string]int{}
+
+	activity := make([]WeekActivity, weeks)
+	weekIndex := map[string]int{}
+	for i := range activity {
+		activity[i].Week = ISOWeek(now.AddDate(0, 0, -7*(weeks-1-i)))
+		weekIndex[activity[i].Week] = i
+	}
+	countWeek := func(obj anytype.Object, key string, count func(*WeekActivity)) {
+		values, _ := propertyValues(obj, key)
+		if len(values) == 0 {
+			return
+		}
+		if t, err := time.Parse(time.RFC3339, values[0]); err == nil {
+			if i, ok := weekIndex[ISOWeek(t.In(now.Location()))]; ok {
+				count(&activity[i])
+			}
+		}
+	}
+
+	for _, obj := range objects {
+		if obj.Archived {
+			stats.Archived++
+			continue
+		}
+		stats.Objects++
+		typeName := obj.TypeKey
+		if obj.Type != nil && obj.Type.Name != "" {
+			typeName = obj.Type.Name
+		}
+		byType[typeName]++
+		byLayout[obj.Layout]++
+		if ListKind(obj) != "" {
+			stats.Lists++
+		}
+		if strings.TrimSpace(obj.Name) == "" {
+			stats.Untitled = append(stats.Untitled, obj)
+		}
+		countWeek(obj, CreatedDateProperty, func(w *WeekActivity) { w.Created++ })
+		countWeek(obj, LastModifiedDateProperty, func(w *WeekActivity) { w.Modified++ })
+
+		if size, ok := bodySizes[obj.ID]; ok {
+			stats.Largest = append(stats.Largest, ObjectSize{ID: obj.ID, Name: obj.Name, Bytes: size})
+		}
+	}
+	for _, member := range members {
+		if member.Status == string(anytype.MemberStatusActive) {
+			byRole[member.Role]++
+		}
+	}
+
+	slices.SortStableFunc(stats.Largest, func(a, b ObjectSize) int { return cmp.Compare(b.Bytes, a.Bytes) })
+	stats.Largest = stats.Largest[:min(top, len(stats.Largest))]
+	stats.ByType = sortedCounts(byType)
+	stats.ByLayout = sortedCounts(byLayout)
+	stats.MembersByRole = sortedCounts(byRole)
+	stats.Weeks = activity
+	return stats
+}
+
+// sortedCounts returns counts by decreasing count, then by name
+func sortedCounts(counts map[string]int) []Count {
+	sorted := make([]Count, 0, len(counts))
+	for name, count := range counts {
+		sorted = append(sorted, Count{Name: name, Count: count})
+	}
+	slices.SortFunc(sorted, func(a, b Count) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), strings.Compare(a.Name, b.Name))
+	})
+	return sorted
+}
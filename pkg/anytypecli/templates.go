@@ -0,0 +1,169 @@
+package anytypecli
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/epheo/anytype-go"
+)
+
+// Templates are objects of the TemplateTypeKey type, linked to the type of the objects
+// they create by the TargetTypeProperty property
+const (
+	TemplateTypeKey    = "template"
+	TargetTypeProperty = "target_object_type"
+)
+
+// CreateTemplateRequest holds the content of a new template
+type CreateTemplateRequest struct {
+	Name string
+	// Body is the markdown body of the objects created from the template
+	Body string
+	Icon *anytype.Icon
+}
+
+// UpdateObjectRequest holds the changes to an object. Empty fields are left unchanged;
+// Properties, written as returned by PropertyValue or ClearedValue, only sets the
+// properties it lists.
+type UpdateObjectRequest struct {
+	Name       string                   `json:"name,omitempty"`
+	Icon       *anytype.Icon            `json:"icon,omitempty"`
+	Markdown   string                   `json:"markdown,omitempty"`
+	TypeKey    string                   `json:"type_key,omitempty"`
+	Properties []map[string]interface{} `json:"properties,omitempty"`
+}
+
+// objectResponse is the envelope of the object endpoints
+type objectResponse struct {
+	Object anytype.Object `json:"object"`
+}
+
+// CreateTemplate creates a template for the objects of a type
+func (a *API) CreateTemplate(ctx context.Context, spaceID, typeKey string, req CreateTemplateRequest) (*anytype.Object, error) {
+	body := map[string]interface{}{
+		"type_key": TemplateTypeKey,
+		"name":     req.Name,
+		"body":     req.Body,
+		"properties": []map[string]interface{}{
+			{"key": TargetTypeProperty, "objects": []string{typeKey}},
+		},
+	}
+	if req.Icon != nil {
+		body["icon"] = req.Icon
+	}
+
+	var resp objectResponse
+	if err := a.Do(ctx, "POST", fmt.Sprintf("/spaces/%s/objects", spaceID), nil, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Object, nil
+}
+
+// UpdateObject applies the changes of req to an object, or to a template, and returns it
+func (a *API) UpdateObject(ctx context.Context, spaceID, objectID string, req UpdateObjectRequest) (*anytype.Object, error) {
+	var resp objectResponse
+	if err := a.Do(ctx, "PATCH", fmt.Sprintf("/spaces/%s/objects/%s", spaceID, objectID), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Object, nil
+}
+
+// ResolveTemplate takes either a template ID or a template name and returns the ID of the
+// corresponding template of a type, with the same matching rules as ResolveSpace
+func ResolveTemplate(ctx context.Context, c anytype.Client, spaceID, typeKey, idOrName string) (string, error) {
+	templates, err := c.Space(spaceID).Type(typeKey).Templates().List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	candidates := make([]Match, 0, len(templates))
+	for _, template := range templates {
+		candidates = append(candidates, Match{ID: template.ID, Name: template.Name})
+	}
+	return resolve("template", candidates, idOrName)
+}
+
+// Built-in template variables, see TemplateVariables. Variables given by the user take precedence.
+const (
+	// VarDate is today's date, as 2006-01-02
+	VarDate = "date"
+	// VarTime is the current time, as 15:04
+	VarTime = "time"
+	// VarWeek is the ISO 8601 week, as 2006-W01
+	VarWeek = "week"
+	// VarYear is the current year
+	VarYear = "year"
+	// VarMe is the name of the current user in the space, see CurrentMemberName
+	VarMe = "me"
+)
+
+// placeholderPattern matches the {{name}} placeholders of a template
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// Placeholders returns the names of the placeholders of texts, sorted and without duplicates
+func Placeholders(texts ...string) []string {
+	var names []string
+	for _, text := range texts {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			names = append(names, match[1])
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// RenderTemplate replaces the {{name}} placeholders of text with the values of vars.
+// Placeholders without a value are left as they are.
+func RenderTemplate(text string, vars map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// ISOWeek returns the ISO 8601 week of t, as 2006-W01
+func ISOWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// TemplateVariables returns the built-in date variables at now
+func TemplateVariables(now time.Time) map[string]string {
+	return map[string]string{
+		VarDate: now.Format("2006-01-02"),
+		VarTime: now.Format("15:04"),
+		VarWeek: ISOWeek(now),
+		VarYear: now.Format("2006"),
+	}
+}
+
+// ParseVariable parses a template variable given as name=value
+func ParseVariable(s string) (name, value string, err error) {
+	name, value, ok := strings.Cut(s, "=")
+	name = strings.TrimSpace(name)
+	if !ok || !placeholderPattern.MatchString("{{"+name+"}}") {
+		return "", "", fmt.Errorf("invalid variable %q, expected name=value", s)
+	}
+	return name, value, nil
+}
+
+// CurrentMemberName returns the name of the current user in a space, which is the
+// name of the profile object of the space
+func CurrentMemberName(ctx context.Context, c anytype.Client, spaceID string) (string, error) {
+	space, err := c.Space(spaceID).Get(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get space: %w", err)
+	}
+	profile, err := c.Space(spaceID).Object(space.Space.ProfileID).Get(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get profile: %w", err)
+	}
+	return profile.Object.Name, nil
+}
@@ -0,0 +1,118 @@
+package anytypecli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/epheo/anytype-go"
+)
+
+// TypeLayouts are the layouts an object type can be created with
+var TypeLayouts = []string{"basic", "profile", "action", "note"}
+
+// PropertyFormats are the formats a property can have
+var PropertyFormats = []string{
+	"text", "number", "select", "multi_select", "date", "files",
+	"checkbox", "url", "email", "phone", "objects",
+}
+
+// ValidTypeLayout reports whether layout is one of TypeLayouts
+func ValidTypeLayout(layout string) bool {
+	return slices.Contains(TypeLayouts, layout)
+}
+
+// ValidPropertyFormat reports whether format is one of PropertyFormats
+func ValidPropertyFormat(format string) bool {
+	return slices.Contains(PropertyFormats, format)
+}
+
+// ParsePropertyDefinition parses a property definition written as "key:format" or
+// "key:format:Name". The name defaults to the key.
+func ParsePropertyDefinition(s string) (anytype.PropertyDefinition, error) {
+	parts := strings.SplitN(s, ":", 3)
+	if len(parts) < 2 || parts[0] == "" {
+		return anytype.PropertyDefinition{}, fmt.Errorf("invalid property definition '%s' (expected key:format or key:format:Name)", s)
+	}
+
+	def := anytype.PropertyDefinition{Key: parts[0], Format: parts[1], Name: parts[0]}
+	if len(parts) == 3 && parts[2] != "" {
+		def.Name = parts[2]
+	}
+	if !ValidPropertyFormat(def.Format) {
+		return anytype.PropertyDefinition{}, fmt.Errorf("invalid format '%s' for property '%s' (expected one of: %s)",
+			def.Format, def.Key, strings.Join(PropertyFormats, ", "))
+	}
+	return def, nil
+}
+
+// ResolveType takes either a type key or a type name and returns the corresponding type key,
+// with the same matching rules as ResolveSpace
+func ResolveType(ctx context.Context, c anytype.Client, spaceID, keyOrName string) (string, error) {
+	types, err := c.Space(spaceID).Types().List(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list types: %w", err)
+	}
+
+	candidates := make([]Match, 0, len(types))
+	for _, typ := range types {
+		candidates = append(candidates, Match{ID: typ.Key, Name: typ.Name})
+	}
+	return resolve("type", candidates, keyOrName)
+}
+
+// UpdateTypeRequest holds the changes to an object type. Empty fields are left unchanged;
+// a non-nil Properties replaces the property definitions of the type, so that pointing it
+// to an empty slice removes them all.
+type UpdateTypeRequest struct {
+	Key        string                        `json:"key,omitempty"`
+	Name       string                        `json:"name,omitempty"`
+	PluralName string                        `json:"plural_name,omitempty"`
+	Layout     string                        `json:"layout,omitempty"`
+	Icon       *anytype.Icon                 `json:"icon,omitempty"`
+	Properties *[]anytype.PropertyDefinition `json:"properties,omitempty"`
+}
+
+// typeResponse is the envelope of the type endpoints
+type typeResponse struct {
+	Type anytype.Type `json:"type"`
+}
+
+// UpdateType applies the changes of req to an object type and returns the updated type
+func (a *API) UpdateType(ctx context.Context, spaceID, typeID string, req UpdateTypeRequest) (*anytype.Type, error) {
+	var resp typeResponse
+	if err := a.Do(ctx, "PATCH", fmt.Sprintf("/spaces/%s/types/%s", spaceID, typeID), nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Type, nil
+}
+
+// DeleteType archives an object type and returns it
+func (a *API) DeleteType(ctx context.Context, spaceID, typeID string) (*anytype.Type, error) {
+	var resp typeResponse
+	if err := a.Do(ctx, "DELETE", fmt.Sprintf("/spaces/%s/types/%s", spaceID, typeID), nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Type, nil
+}
+
+// MergePropertyDefinitions returns defs with the definitions of add appended, replacing those
+// with the same key, and the definitions whose key is in remove left out. Removing a key the
+// type does not have is an error.
+func MergePropertyDefinitions(defs, add []anytype.PropertyDefinition, remove []string) ([]anytype.PropertyDefinition, error) {
+	for _, key := range remove {
+		if !slices.ContainsFunc(defs, func(def anytype.PropertyDefinition) bool { return def.Key == key }) {
+			return nil, fmt.Errorf("the type has no property '%s'", key)
+		}
+	}
+
+	merged := []anytype.PropertyDefinition{}
+	for _, def := range defs {
+		replaced := slices.ContainsFunc(add, func(a anytype.PropertyDefinition) bool { return a.Key == def.Key })
+		if !replaced && !slices.Contains(remove, def.Key) {
+			merged = append(merged, def)
+		}
+	}
+	return append(merged, add...), nil
+}
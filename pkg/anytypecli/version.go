@@ -0,0 +1,6 @@
+package anytypecli
+
+// Version is the semantic version of this package's API. It is incremented
+// independently of the CLI and of the Anytype SDK: the major version changes
+// when an exported identifier is removed or changes behavior incompatibly.
+const Version = "0.1.0"
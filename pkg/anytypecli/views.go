@@ -0,0 +1,83 @@
+package anytypecli
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/epheo/anytype-go"
+)
+
+// The API describes a view by its layout, filters and sorts only: the property a kanban
+// view is grouped by, the properties a grid view shows and the date property of a calendar
+// view are not part of it. The helpers below let callers infer them from the objects.
+
+// ObjectGroup is a column of a kanban view: the objects sharing a value of the group-by property
+type ObjectGroup struct {
+	// Name is the value of the group-by property, empty for the objects without one
+	Name    string           `json:"name" yaml:"name"`
+	Objects []anytype.Object `json:"objects" yaml:"objects"`
+}
+
+// PropertyText returns the value of a property of an object as text, multiple values being
+// joined with commas and dates written as 2006-01-02. The keys "name" and "type" designate
+// the name and type key of the object.
+func PropertyText(obj anytype.Object, key string) string {
+	values, format := propertyValues(obj, key)
+	if format == "date" && len(values) == 1 {
+		if t, err := time.Parse(time.RFC3339, values[0]); err == nil {
+			return t.Format("2006-01-02")
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+// PropertyKeys returns the keys of the properties the objects have a value for, in order of
+// first appearance, keeping only the given formats if any
+func PropertyKeys(objects []anytype.Object, formats ...string) []string {
+	var keys []string
+	for _, obj := range objects {
+		for _, property := range obj.Properties {
+			if len(formats) > 0 && !slices.Contains(formats, property.Format) {
+				continue
+			}
+			if values, _ := propertyValues(obj, property.Key); len(values) > 0 && !slices.Contains(keys, property.Key) {
+				keys = append(keys, property.Key)
+			}
+		}
+	}
+	return keys
+}
+
+// GroupObjects groups objects by the values of a property, an object with several values
+// appearing in several groups. Groups come in the order of names, which may list values
+// no object has, followed by the other values in order of appearance; the group of the
+// objects without a value comes last.
+func GroupObjects(objects []anytype.Object, key string, names []string) []ObjectGroup {
+	groups := make([]ObjectGroup, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, ObjectGroup{Name: name, Objects: []anytype.Object{}})
+	}
+	var none []anytype.Object
+
+	for _, obj := range objects {
+		values, _ := propertyValues(obj, key)
+		if len(values) == 0 {
+			none = append(none, obj)
+			continue
+		}
+		for _, value := range values {
+			i := slices.IndexFunc(groups, func(g ObjectGroup) bool { return strings.EqualFold(g.Name, value) })
+			if i < 0 {
+				groups = append(groups, ObjectGroup{Name: value})
+				i = len(groups) - 1
+			}
+			groups[i].Objects = append(groups[i].Objects, obj)
+		}
+	}
+
+	if len(none) > 0 {
+		groups = append(groups, ObjectGroup{Objects: none})
+	}
+	return groups
+}